@@ -49,10 +49,10 @@ func main() {
 		return
 	}
 
-	// 动态自定义敏感词白名单
-	err = filter.Store.DelWord("武汉海鲜市场", "武汉")
+	// 动态添加白名单短语（仅放行短语内的命中，其他位置的“武汉”仍会命中）
+	err = filter.Store.AddAllowWord("武汉海鲜市场")
 	if err != nil {
-		log.Fatalf("删除敏感词发生了错误, err:%v", err)
+		log.Fatalf("添加白名单发生了错误, err:%v", err)
 		return
 	}
 
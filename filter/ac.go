@@ -0,0 +1,157 @@
+package filter
+
+// AC 自动机节点结构
+type acNode struct {
+	children map[rune]*acNode // 子节点
+	fail     *acNode          // 失配指针
+	output   *acNode          // 沿失配链最近的词尾节点（输出链）
+	depth    int              // 节点深度，即从根到该节点的字符数
+	isLeaf   bool             // 是否为词尾
+}
+
+func newAcNode(depth int) *acNode {
+	return &acNode{
+		children: make(map[rune]*acNode),
+		depth:    depth,
+	}
+}
+
+// AcModel 是基于 Aho-Corasick 自动机的敏感词匹配器
+// 借助失配指针，每段文本只需线性扫描一遍。
+// 词库变更会先记录到词集合中，在后台批量重建自动机，重建完成前查询仍使用原自动机，需要立即生效时调用 Flush。
+type AcModel struct {
+	matchConfig
+	*batchBuilder[*acNode]
+}
+
+func NewAcModel() *AcModel {
+	return &AcModel{
+		batchBuilder: newBatchBuilder(buildAc),
+	}
+}
+
+// 根据词集合构建 AC 自动机
+func buildAc(words map[string]struct{}) *acNode {
+	root := newAcNode(0)
+
+	for word := range words {
+		now := root
+		for _, r := range word {
+			next, ok := now.children[r]
+			if !ok {
+				next = newAcNode(now.depth + 1)
+				now.children[r] = next
+			}
+			now = next
+		}
+		now.isLeaf = true
+	}
+
+	// 按层遍历设置失配指针与输出链
+	queue := make([]*acNode, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		now := queue[0]
+		queue = queue[1:]
+
+		for r, child := range now.children {
+			fail := now.fail
+			for fail != root && fail.children[r] == nil {
+				fail = fail.fail
+			}
+			if next, ok := fail.children[r]; ok && next != child {
+				child.fail = next
+			} else {
+				child.fail = root
+			}
+
+			if child.fail.isLeaf {
+				child.output = child.fail
+			} else {
+				child.output = child.fail.output
+			}
+
+			queue = append(queue, child)
+		}
+	}
+
+	return root
+}
+
+// 线性扫描文本，对每个命中回调 fn(h)，区间为左闭右开的 rune 下标
+// fn 返回 false 时停止扫描
+func (m *AcModel) scan(runes []rune, fn func(h hit) bool) {
+	root := m.current()
+	now := root
+
+	for pos, r := range runes {
+		for now != root && now.children[r] == nil {
+			now = now.fail
+		}
+		if next, ok := now.children[r]; ok {
+			now = next
+		}
+
+		out := now
+		if !out.isLeaf {
+			out = out.output
+		}
+		for ; out != nil; out = out.output {
+			if !fn(hit{start: pos + 1 - out.depth, end: pos + 1}) {
+				return
+			}
+		}
+	}
+}
+
+// 查找文本中所有敏感词命中（按文本顺序）
+func (m *AcModel) FindAllMatches(text string) []Match {
+	var hits []hit
+	runes := []rune(text)
+
+	m.scan(runes, func(h hit) bool {
+		hits = append(hits, h)
+		return true
+	})
+
+	return m.newMatches(text, runes, hits)
+}
+
+// 查找文本中所有敏感词
+func (m *AcModel) FindAll(text string) []string {
+	return findAll(m.FindAllMatches(text))
+}
+
+// 查找所有敏感词及其出现次数
+func (m *AcModel) FindAllCount(text string) map[string]int {
+	return findAllCount(m.FindAllMatches(text))
+}
+
+// 查找一个敏感词（返回文本中第一个命中）
+func (m *AcModel) FindOne(text string) string {
+	return findOne(m.FindAllMatches(text))
+}
+
+// 判断文本中是否包含敏感词
+func (m *AcModel) IsSensitive(text string) bool {
+	return m.isSensitive(text, m.scan)
+}
+
+// 将敏感词替换为指定字符（如 *）
+func (m *AcModel) Replace(text string, repl rune) string {
+	return replaceMatches(text, m.FindAllMatches(text), repl)
+}
+
+// 使用回调函数的返回值替换每个敏感词，重叠的命中会先合并为一段
+func (m *AcModel) ReplaceFunc(text string, fn func(Match) string) string {
+	return ReplaceMatchesFunc(text, m.FindAllMatches(text), fn)
+}
+
+// 将敏感词从文本中完全移除
+func (m *AcModel) Remove(text string) string {
+	return removeMatches(text, m.FindAllMatches(text))
+}
@@ -0,0 +1,47 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAcModel(t *testing.T) {
+	words := []string{"武汉", "武汉海鲜市场", "海鲜", "毒品", "台湾国", "湾国人"}
+	texts := []string{
+		"",
+		"无敏感词的文本",
+		"武汉海鲜市场",
+		"小明对毒品销售说，我认为台湾国人有点意思",
+		"武汉武汉海鲜海鲜市",
+	}
+
+	ac := NewAcModel()
+	ac.AddWords(words...)
+	ac.Flush()
+	dfa := NewDfaModel()
+	dfa.AddWords(words...)
+
+	for _, text := range texts {
+		if got, want := ac.FindAllCount(text), dfa.FindAllCount(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAllCount(%q) = %v, want %v", text, got, want)
+		}
+		if got, want := ac.IsSensitive(text), dfa.IsSensitive(text); got != want {
+			t.Errorf("IsSensitive(%q) = %v, want %v", text, got, want)
+		}
+	}
+
+	if got := ac.Replace("我认为台湾国人有点意思", '*'); got != "我认为****有点意思" {
+		t.Errorf("Replace = %q", got)
+	}
+	if got := ac.Remove("我认为台湾国人有点意思"); got != "我认为有点意思" {
+		t.Errorf("Remove = %q", got)
+	}
+
+	// 动态增删在 Flush 后生效
+	ac.DelWords("武汉", "海鲜")
+	ac.AddWord("市场")
+	ac.Flush()
+	if got, want := ac.FindAll("武汉海鲜市场"), []string{"武汉海鲜市场", "市场"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll after update = %v, want %v", got, want)
+	}
+}
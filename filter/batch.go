@@ -0,0 +1,155 @@
+package filter
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 首个未构建的变更之后等待的时间，期间的变更合并为一次重建
+const batchDelay = 10 * time.Millisecond
+
+// batchBuilder 记录词集合的增删，并在后台批量重建匹配结构
+// 适用于 AC 自动机、双数组 Trie 等构建后不便原地修改的算法。
+// 构建好的匹配结构只读，通过原子指针发布；查询始终使用最近一次构建的结构，既不加锁也不等待重建。
+type batchBuilder[T any] struct {
+	mu           sync.Mutex
+	rebuilt      sync.Cond                         // 每次重建完成时广播，与 mu 配合使用
+	words        map[string]struct{}               // 当前词集合，仅在持有 mu 时访问
+	build        func(words map[string]struct{}) T // 构建函数
+	version      uint64                            // 词集合的版本，每次变更加 1
+	builtVersion uint64                            // 已发布的匹配结构对应的版本
+	timer        *time.Timer                       // 等待中的重建，没有时为 nil
+	building     bool                              // 是否正在后台重建
+
+	built atomic.Pointer[T] // 最近一次构建的匹配结构
+}
+
+func newBatchBuilder[T any](build func(words map[string]struct{}) T) *batchBuilder[T] {
+	b := &batchBuilder[T]{
+		words: make(map[string]struct{}),
+		build: build,
+	}
+	b.rebuilt.L = &b.mu
+	built := build(nil)
+	b.built.Store(&built)
+
+	return b
+}
+
+// 添加多个词
+func (b *batchBuilder[T]) AddWords(words ...string) {
+	for _, word := range words {
+		b.AddWord(word)
+	}
+}
+
+// 添加单个词（在后台批量重建后生效）
+func (b *batchBuilder[T]) AddWord(word string) {
+	if word == "" {
+		return
+	}
+
+	b.mu.Lock()
+	if _, ok := b.words[word]; !ok {
+		b.words[word] = struct{}{}
+		b.changed()
+	}
+	b.mu.Unlock()
+}
+
+// 删除多个词
+func (b *batchBuilder[T]) DelWords(words ...string) {
+	for _, word := range words {
+		b.DelWord(word)
+	}
+}
+
+// 删除单个词（在后台批量重建后生效）
+func (b *batchBuilder[T]) DelWord(word string) {
+	if word == "" {
+		return
+	}
+
+	b.mu.Lock()
+	if _, ok := b.words[word]; ok {
+		delete(b.words, word)
+		b.changed()
+	}
+	b.mu.Unlock()
+}
+
+// 监听新增和删除通道，收到同步信号（空字符串）时等待此前的变更生效
+func (b *batchBuilder[T]) Listen(addChan, delChan <-chan string) {
+	go func() {
+		for word := range addChan {
+			if word == "" {
+				b.Flush()
+				continue
+			}
+			b.AddWord(word)
+		}
+	}()
+
+	go func() {
+		for word := range delChan {
+			if word == "" {
+				b.Flush()
+				continue
+			}
+			b.DelWord(word)
+		}
+	}()
+}
+
+// Flush 立即重建并等待此前的增删词对查询生效
+func (b *batchBuilder[T]) Flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// 跳过等待中的延迟，已触发的重建会自行完成
+	if b.timer != nil && b.timer.Stop() {
+		b.timer = nil
+		go b.rebuild()
+	}
+	for b.builtVersion < b.version {
+		b.rebuilt.Wait()
+	}
+}
+
+// 记录一次变更，没有等待中或进行中的重建时延迟 batchDelay 后重建，调用时须持有 mu
+func (b *batchBuilder[T]) changed() {
+	b.version++
+	if b.timer == nil && !b.building {
+		b.timer = time.AfterFunc(batchDelay, b.rebuild)
+	}
+}
+
+// 在后台重建匹配结构，重建期间的变更在本次完成后接着重建
+// 构建时不持有 mu，增删词与查询都不会被阻塞。
+func (b *batchBuilder[T]) rebuild() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.timer = nil
+	if b.building {
+		return
+	}
+	b.building = true
+	for b.builtVersion < b.version {
+		version, words := b.version, maps.Clone(b.words)
+		b.mu.Unlock()
+		built := b.build(words)
+		b.built.Store(&built)
+		b.mu.Lock()
+		b.builtVersion = version
+		b.rebuilt.Broadcast()
+	}
+	b.building = false
+}
+
+// 获取最近一次构建的匹配结构
+func (b *batchBuilder[T]) current() T {
+	return *b.built.Load()
+}
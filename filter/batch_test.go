@@ -0,0 +1,45 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+// 后台重建期间，查询与增删词都立即返回，查询使用上一次构建的结构
+func TestBatchBuilderRebuildInBackground(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	b := newBatchBuilder(func(words map[string]struct{}) int {
+		if len(words) > 0 {
+			started <- struct{}{}
+			<-release
+		}
+		return len(words)
+	})
+
+	b.AddWord("武汉")
+	<-started
+
+	done := make(chan int)
+	go func() {
+		b.AddWord("海鲜")
+		done <- b.current()
+	}()
+	select {
+	case got := <-done:
+		if got != 0 {
+			t.Errorf("current during rebuild = %d, want 0", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AddWord or current waited for the pending rebuild")
+	}
+
+	// 重建期间的变更在本次完成后接着重建
+	release <- struct{}{}
+	<-started
+	close(release)
+	b.Flush()
+	if got := b.current(); got != 2 {
+		t.Errorf("current after Flush = %d, want 2", got)
+	}
+}
@@ -0,0 +1,46 @@
+package filter
+
+import "sync/atomic"
+
+// MatchMode 重叠命中的处理策略
+type MatchMode uint32
+
+const (
+	MatchAllOverlapping   MatchMode = iota // 返回所有命中，包括相互重叠的命中（默认）
+	MatchLeftmostLongest                   // 从左到右依次取最长命中，结果互不重叠
+	MatchLeftmostShortest                  // 从左到右依次取最短命中，结果互不重叠
+)
+
+// matchConfig 是各匹配器共用的匹配配置
+type matchConfig struct {
+	mode  atomic.Uint32          // 重叠命中的处理策略
+	allow atomic.Pointer[Filter] // 白名单短语匹配器
+}
+
+// SetMatchMode 设置重叠命中的处理策略，FindAllMatches、FindAll、FindOne、Replace 等方法统一遵循该策略
+func (c *matchConfig) SetMatchMode(mode MatchMode) {
+	c.mode.Store(uint32(mode))
+}
+
+// MatchMode 返回当前的重叠命中处理策略
+func (c *matchConfig) MatchMode() MatchMode {
+	return MatchMode(c.mode.Load())
+}
+
+// SetAllowList 设置白名单短语匹配器，完全落在白名单短语内的命中会被忽略，传入 nil 取消白名单
+// 白名单过滤先于重叠策略执行，例如白名单“南京市长江大桥”只放行其中的“市长”，其他位置的“市长”仍会命中。
+func (c *matchConfig) SetAllowList(allow Filter) {
+	if allow == nil {
+		c.allow.Store(nil)
+		return
+	}
+	c.allow.Store(&allow)
+}
+
+// AllowList 返回当前的白名单短语匹配器，未设置时返回 nil
+func (c *matchConfig) AllowList() Filter {
+	if allow := c.allow.Load(); allow != nil {
+		return *allow
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package filter
+
+import "sort"
+
+// 双数组中的词尾标记编码，作为每个词尾节点的一个特殊子节点
+const datEndCode = 0
+
+// doubleArray 是双数组 Trie 的存储结构
+// 所有字段均不含指针，GC 无需扫描其内容。
+type doubleArray struct {
+	codes map[rune]int32 // 字符编码表，编码从 1 开始
+	base  []int32        // 状态 s 经编码 c 转移到 base[s]+c
+	check []int32        // check[t] 为父状态下标加一，0 表示空闲
+	words int            // 构建时的词数
+	nodes int            // 已占用的状态数（含词尾标记）
+}
+
+// DatStats 是双数组 Trie 的内存与节点统计
+type DatStats struct {
+	Words int // 词数
+	Nodes int // 已占用的状态数（含词尾标记）
+	Size  int // 双数组长度
+	Runes int // 字符编码表大小
+	Bytes int // base/check 数组与编码表的估算内存（字节）
+}
+
+// DatModel 是基于双数组 Trie 的敏感词匹配器
+// 与 DfaModel 的 map 指针树相比，双数组只占用少量连续内存，适合加载大词库。
+// 词库变更会先记录到词集合中，在后台批量重建双数组，重建完成前查询仍使用原双数组，需要立即生效时调用 Flush。
+type DatModel struct {
+	matchConfig
+	*batchBuilder[*doubleArray]
+}
+
+func NewDatModel() *DatModel {
+	return &DatModel{
+		batchBuilder: newBatchBuilder(buildDat),
+	}
+}
+
+// 构建双数组时使用的临时 Trie 节点
+type datBuildNode struct {
+	children map[int32]*datBuildNode
+	isLeaf   bool
+}
+
+// 根据词集合构建双数组 Trie
+func buildDat(words map[string]struct{}) *doubleArray {
+	da := &doubleArray{
+		codes: make(map[rune]int32),
+		words: len(words),
+	}
+
+	// 按字符频次从高到低分配编码，高频字符编码更小，数组更紧凑
+	freq := make(map[rune]int)
+	for word := range words {
+		for _, r := range word {
+			freq[r]++
+		}
+	}
+	runes := make([]rune, 0, len(freq))
+	for r := range freq {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool {
+		if freq[runes[i]] != freq[runes[j]] {
+			return freq[runes[i]] > freq[runes[j]]
+		}
+		return runes[i] < runes[j]
+	})
+	for i, r := range runes {
+		da.codes[r] = int32(i + 1)
+	}
+
+	root := &datBuildNode{children: make(map[int32]*datBuildNode)}
+	for word := range words {
+		now := root
+		for _, r := range word {
+			code := da.codes[r]
+			next, ok := now.children[code]
+			if !ok {
+				next = &datBuildNode{children: make(map[int32]*datBuildNode)}
+				now.children[code] = next
+			}
+			now = next
+		}
+		now.isLeaf = true
+	}
+
+	da.grow(1)
+	da.check[0] = 1 // 根节点占用下标 0
+	da.nodes = 1
+
+	type item struct {
+		node  *datBuildNode
+		state int32
+	}
+	queue := []item{{node: root, state: 0}}
+	nextFree := int32(1)
+
+	for len(queue) > 0 {
+		now := queue[0]
+		queue = queue[1:]
+
+		codes := make([]int32, 0, len(now.node.children)+1)
+		if now.node.isLeaf {
+			codes = append(codes, datEndCode)
+		}
+		for code := range now.node.children {
+			codes = append(codes, code)
+		}
+		if len(codes) == 0 {
+			continue
+		}
+		sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+		base := da.findBase(codes, &nextFree)
+		da.base[now.state] = base
+		for _, code := range codes {
+			da.check[base+code] = now.state + 1
+			da.nodes++
+			if code != datEndCode {
+				queue = append(queue, item{node: now.node.children[code], state: base + code})
+			}
+		}
+	}
+
+	return da
+}
+
+// 为一组有序编码寻找可用的 base，使 base+code 全部空闲
+func (da *doubleArray) findBase(codes []int32, nextFree *int32) int32 {
+	// 跳过前部已被占满的区域
+	for int(*nextFree) < len(da.check) && da.check[*nextFree] != 0 {
+		*nextFree++
+	}
+
+	first := codes[0]
+	for pos := *nextFree; ; pos++ {
+		base := pos - first
+		if base < 1 {
+			continue
+		}
+		da.grow(int(base + codes[len(codes)-1] + 1))
+		if da.check[pos] != 0 {
+			continue
+		}
+
+		ok := true
+		for _, code := range codes[1:] {
+			if da.check[base+code] != 0 {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return base
+		}
+	}
+}
+
+// 扩容双数组，保证长度不小于 size
+func (da *doubleArray) grow(size int) {
+	if size <= len(da.check) {
+		return
+	}
+
+	newSize := len(da.check) * 2
+	if newSize < size {
+		newSize = size
+	}
+	da.base = append(da.base, make([]int32, newSize-len(da.base))...)
+	da.check = append(da.check, make([]int32, newSize-len(da.check))...)
+}
+
+// 从状态 s 经编码 code 转移，失败时返回 -1
+func (da *doubleArray) next(s, code int32) int32 {
+	t := da.base[s] + code
+	if da.base[s] == 0 || int(t) >= len(da.check) || da.check[t] != s+1 {
+		return -1
+	}
+	return t
+}
+
+// 扫描文本，对每个命中回调 fn(h)，区间为左闭右开的 rune 下标
+// fn 返回 false 时停止扫描
+func (m *DatModel) scan(runes []rune, fn func(h hit) bool) {
+	da := m.current()
+
+	for start := range runes {
+		s := int32(0)
+		for pos := start; pos < len(runes); pos++ {
+			code, ok := da.codes[runes[pos]]
+			if !ok {
+				break
+			}
+			if s = da.next(s, code); s < 0 {
+				break
+			}
+			if da.next(s, datEndCode) >= 0 && !fn(hit{start: start, end: pos + 1}) {
+				return
+			}
+		}
+	}
+}
+
+// Stats 返回当前双数组的内存与节点统计
+func (m *DatModel) Stats() DatStats {
+	da := m.current()
+
+	return DatStats{
+		Words: da.words,
+		Nodes: da.nodes,
+		Size:  len(da.base),
+		Runes: len(da.codes),
+		Bytes: len(da.base)*4 + len(da.check)*4 + len(da.codes)*8,
+	}
+}
+
+// 查找文本中所有敏感词命中（按文本顺序）
+func (m *DatModel) FindAllMatches(text string) []Match {
+	var hits []hit
+	runes := []rune(text)
+
+	m.scan(runes, func(h hit) bool {
+		hits = append(hits, h)
+		return true
+	})
+
+	return m.newMatches(text, runes, hits)
+}
+
+// 查找文本中所有敏感词
+func (m *DatModel) FindAll(text string) []string {
+	return findAll(m.FindAllMatches(text))
+}
+
+// 查找所有敏感词及其出现次数
+func (m *DatModel) FindAllCount(text string) map[string]int {
+	return findAllCount(m.FindAllMatches(text))
+}
+
+// 查找一个敏感词（返回文本中第一个命中）
+func (m *DatModel) FindOne(text string) string {
+	return findOne(m.FindAllMatches(text))
+}
+
+// 判断文本中是否包含敏感词
+func (m *DatModel) IsSensitive(text string) bool {
+	return m.isSensitive(text, m.scan)
+}
+
+// 将敏感词替换为指定字符（如 *）
+func (m *DatModel) Replace(text string, repl rune) string {
+	return replaceMatches(text, m.FindAllMatches(text), repl)
+}
+
+// 使用回调函数的返回值替换每个敏感词，重叠的命中会先合并为一段
+func (m *DatModel) ReplaceFunc(text string, fn func(Match) string) string {
+	return ReplaceMatchesFunc(text, m.FindAllMatches(text), fn)
+}
+
+// 将敏感词从文本中完全移除
+func (m *DatModel) Remove(text string) string {
+	return removeMatches(text, m.FindAllMatches(text))
+}
@@ -0,0 +1,50 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDatModel(t *testing.T) {
+	words := []string{"武汉", "武汉海鲜市场", "海鲜", "毒品", "台湾国", "湾国人", "a", "ab"}
+	texts := []string{
+		"",
+		"无敏感词的文本",
+		"武汉海鲜市场",
+		"小明对毒品销售说，我认为台湾国人有点意思",
+		"武汉武汉海鲜海鲜市 abc",
+	}
+
+	dat := NewDatModel()
+	dat.AddWords(words...)
+	dat.Flush()
+	dfa := NewDfaModel()
+	dfa.AddWords(words...)
+
+	for _, text := range texts {
+		if got, want := dat.FindAll(text), dfa.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAll(%q) = %v, want %v", text, got, want)
+		}
+		if got, want := dat.FindAllCount(text), dfa.FindAllCount(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAllCount(%q) = %v, want %v", text, got, want)
+		}
+		if got, want := dat.FindOne(text), dfa.FindOne(text); got != want {
+			t.Errorf("FindOne(%q) = %q, want %q", text, got, want)
+		}
+	}
+
+	if got := dat.Replace("我认为台湾国人有点意思", '*'); got != "我认为****有点意思" {
+		t.Errorf("Replace = %q", got)
+	}
+
+	stats := dat.Stats()
+	if stats.Words != len(words) || stats.Nodes == 0 || stats.Size < stats.Nodes {
+		t.Errorf("Stats = %+v", stats)
+	}
+
+	dat.DelWord("武汉")
+	dat.Flush()
+	if got, want := dat.FindAll("武汉海鲜市场"), []string{"武汉海鲜市场", "海鲜"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll after DelWord = %v, want %v", got, want)
+	}
+}
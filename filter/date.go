@@ -0,0 +1,232 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/zmexing/go-sensitive-word/normalize"
+	"strconv"
+	"sync/atomic"
+)
+
+// DateRule 敏感日期规则，声明一次即可识别各种常见写法
+// 例如 {Word: "六四", Month: 6, Day: 4} 可以命中“6月4日”“六月四号”“1989年6月4日”“6/4”“1989.6.4”“1989-06-04”，
+// 以及“5月35日”“五月三十五”这类由前一个月溢出得到的日期和“8平方”这类月日数字的平方写法。
+type DateRule struct {
+	Word   string // 命中时报告的词，为空时为“6月4日”“1989年6月4日”形式
+	Year   int    // 年份，0 表示任意年份（包括不带年份的写法）；指定年份时只命中带相同年份的写法
+	Month  int
+	Day    int
+	Weight int // 命中的权重，用于风险评分，Manager 中为 0 时按 store.DefaultWeight 计
+}
+
+// 命中时报告的词
+func (r DateRule) word() string {
+	if r.Word != "" {
+		return r.Word
+	}
+	if r.Year != 0 {
+		return fmt.Sprintf("%d年%d月%d日", r.Year, r.Month, r.Day)
+	}
+	return fmt.Sprintf("%d月%d日", r.Month, r.Day)
+}
+
+// 月日数字连写后的数值，如 6 月 4 日为 64，用于匹配“8平方”
+func (r DateRule) digits() int {
+	v, _ := strconv.Atoi(strconv.Itoa(r.Month) + strconv.Itoa(r.Day))
+	return v
+}
+
+// 判断日期写法是否表示该日期
+// 平方写法不带年份，只用于不限年份的规则。
+func (r DateRule) match(form dateForm) bool {
+	if form.square > 0 {
+		return r.Year == 0 && form.square == r.digits()
+	}
+
+	year := -1
+	if r.Year != 0 {
+		if !form.hasYear(r.Year) {
+			return false
+		}
+		year = r.Year
+	} else if form.yearDigits == 4 {
+		year = form.year
+	}
+
+	month, day, ok := resolveDate(year, form.month, form.day)
+	return ok && month == r.Month && day == r.Day
+}
+
+// 将溢出的日期（如 5 月 35 日）顺延为实际的日期（6 月 4 日），year 为 -1 表示年份未知
+func resolveDate(year, month, day int) (int, int, bool) {
+	if month < 1 || month > 12 || day < 1 {
+		return 0, 0, false
+	}
+	for day > daysIn(year, month) {
+		day -= daysIn(year, month)
+		if month++; month > 12 {
+			return 0, 0, false
+		}
+	}
+
+	return month, day, true
+}
+
+// 返回月份的天数，年份未知时二月按 29 天计
+func daysIn(year, month int) int {
+	switch month {
+	case 2:
+		if year < 0 || year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
+
+// DateModel 在匹配器之外识别敏感日期，日期命中与词库命中一起按文本顺序返回，同样遵循重叠策略与白名单
+// 识别前会删除不可见字符并经过 normalize.Numerals，因此“陆月肆日”“⑥/④”等写法同样识别。
+type DateModel struct {
+	matchConfig
+	model Model
+	rules atomic.Pointer[[]DateRule]
+}
+
+// NewDateModel 创建在 model 的命中之外识别敏感日期的匹配器
+func NewDateModel(model Model) *DateModel {
+	return &DateModel{model: model}
+}
+
+// 识别日期前的规范化
+var dateNormalizer = normalize.Pipeline{normalize.StripInvisible, normalize.Numerals}
+
+// SetDates 设置敏感日期规则，替换之前的规则
+func (m *DateModel) SetDates(rules ...DateRule) error {
+	for _, rule := range rules {
+		if rule.Year < 0 || rule.Weight < 0 || rule.Month < 1 || rule.Month > 12 || rule.Day < 1 {
+			return errors.New("invalid date rule")
+		}
+		year := -1
+		if rule.Year != 0 {
+			year = rule.Year
+		}
+		if rule.Day > daysIn(year, rule.Month) {
+			return errors.New("invalid date rule")
+		}
+	}
+
+	rules = append([]DateRule(nil), rules...)
+	m.rules.Store(&rules)
+	return nil
+}
+
+// Dates 返回当前的敏感日期规则
+func (m *DateModel) Dates() []DateRule {
+	if rules := m.rules.Load(); rules != nil {
+		return append([]DateRule(nil), *rules...)
+	}
+	return nil
+}
+
+// Unwrap 返回被包装的匹配器
+func (m *DateModel) Unwrap() Model {
+	return m.model
+}
+
+// 添加单个词
+func (m *DateModel) AddWord(word string) {
+	m.model.AddWord(word)
+}
+
+// 删除单个词
+func (m *DateModel) DelWord(word string) {
+	m.model.DelWord(word)
+}
+
+// 监听新增和删除通道
+func (m *DateModel) Listen(addChan, delChan <-chan string) {
+	m.model.Listen(addChan, delChan)
+}
+
+// Flush 等待此前的增删词对查询生效
+func (m *DateModel) Flush() {
+	m.model.Flush()
+}
+
+// 扫描文本中的敏感日期，位置为原文中的 rune 下标
+func (m *DateModel) scan(runes []rune, fn func(h hit) bool) {
+	rules := m.rules.Load()
+	if rules == nil || len(*rules) == 0 {
+		return
+	}
+
+	text := normalize.NewText(dateNormalizer, runes)
+	for _, form := range scanDates(text.Runes) {
+		for _, rule := range *rules {
+			if !rule.match(form) {
+				continue
+			}
+			span := text.Span(form.start, form.end)
+			if !fn(hit{start: span.Start, end: span.End, word: rule.word(), weight: rule.Weight}) {
+				return
+			}
+		}
+	}
+}
+
+// 查找文本中所有命中（按文本顺序），包括词库命中与敏感日期
+func (m *DateModel) FindAllMatches(text string) []Match {
+	matches := m.model.FindAllMatches(text)
+	runes := []rune(text)
+
+	var hits []hit
+	m.scan(runes, func(h hit) bool {
+		hits = append(hits, h)
+		return true
+	})
+	if len(hits) == 0 {
+		return matches
+	}
+
+	for _, match := range matches {
+		hits = append(hits, hit{start: match.Start, end: match.End, word: match.Word, weight: match.Weight})
+	}
+	return m.newMatches(text, runes, hits)
+}
+
+// 查找文本中所有敏感词
+func (m *DateModel) FindAll(text string) []string {
+	return findAll(m.FindAllMatches(text))
+}
+
+// 查找所有敏感词及其出现次数
+func (m *DateModel) FindAllCount(text string) map[string]int {
+	return findAllCount(m.FindAllMatches(text))
+}
+
+// 查找一个敏感词（返回文本中第一个命中）
+func (m *DateModel) FindOne(text string) string {
+	return findOne(m.FindAllMatches(text))
+}
+
+// 判断文本中是否包含敏感词或敏感日期
+func (m *DateModel) IsSensitive(text string) bool {
+	return m.model.IsSensitive(text) || m.isSensitive(text, m.scan)
+}
+
+// 将敏感词替换为指定字符（如 *）
+func (m *DateModel) Replace(text string, repl rune) string {
+	return replaceMatches(text, m.FindAllMatches(text), repl)
+}
+
+// 使用回调函数的返回值替换每个敏感词，重叠的命中会先合并为一段
+func (m *DateModel) ReplaceFunc(text string, fn func(Match) string) string {
+	return ReplaceMatchesFunc(text, m.FindAllMatches(text), fn)
+}
+
+// 将敏感词从文本中完全移除
+func (m *DateModel) Remove(text string) string {
+	return removeMatches(text, m.FindAllMatches(text))
+}
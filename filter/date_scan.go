@@ -0,0 +1,231 @@
+package filter
+
+import "strings"
+
+// 识别到的一处日期写法，位置为规范化文本中的 rune 下标（左闭右开）
+type dateForm struct {
+	start, end int
+	year       int // 年份，yearDigits 为 0 时无效
+	yearDigits int // 年份的位数：4 为完整年份，2 为“89年”这类省略写法，0 为不带年份
+	month, day int // 月、日，日可能超出当月天数（如“5月35日”）
+	square     int // “8平方”写法时为平方值，此时其他字段无效
+}
+
+// 判断写法中的年份是否为 year
+func (f dateForm) hasYear(year int) bool {
+	switch f.yearDigits {
+	case 4:
+		return f.year == year
+	case 2:
+		return f.year == year%100
+	}
+	return false
+}
+
+// 日期中的数字字符：ASCII 数字（其他数字写法已由 normalize.Numerals 转换）与表示数位的“十”“廿”“卅”
+func isDateNumber(r rune) bool {
+	return '0' <= r && r <= '9' || r == '十' || r == '廿' || r == '卅'
+}
+
+// 数字写法中年、月、日之间的分隔符，如“6/4”“1989.6.4”“1989-06-04”
+func isDateSeparator(r rune) bool {
+	switch r {
+	case '/', '／', '.', '．', '-', '－', '·':
+		return true
+	}
+	return false
+}
+
+func isASCIILetter(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z'
+}
+
+// “平方”之后表示面积单位的字，如“8平方米”“8平方公里”不是日期
+const areaUnits = "米公千厘分毫英尺里码"
+
+// 读取从 i 开始的一个数，返回数值、位数与结束位置
+// 纯数字按十进制读取；含“十”“廿”“卅”的汉字数字（如“3十5”即“三十五”）位数为 0。
+func readNumber(text []rune, i int) (value, digits, end int, ok bool) {
+	end = i
+	for end < len(text) && isDateNumber(text[end]) {
+		end++
+	}
+	if end == i || end-i > 8 {
+		return 0, 0, 0, false
+	}
+
+	tens := -1 // 数位字的位置
+	for k := i; k < end; k++ {
+		if text[k] < '0' || text[k] > '9' {
+			if tens >= 0 {
+				return 0, 0, 0, false
+			}
+			tens = k
+		}
+	}
+	if tens < 0 {
+		for _, r := range text[i:end] {
+			value = value*10 + int(r-'0')
+		}
+		return value, end - i, end, true
+	}
+
+	// 数位字前最多一位数字（仅“十”），后最多一位数字
+	if tens-i > 1 || end-tens > 2 {
+		return 0, 0, 0, false
+	}
+	switch text[tens] {
+	case '十':
+		value = 10
+		if tens > i {
+			value = int(text[i]-'0') * 10
+		}
+	case '廿':
+		value = 20
+	case '卅':
+		value = 30
+	}
+	if text[tens] != '十' && tens > i || value == 0 {
+		return 0, 0, 0, false
+	}
+	if tens+1 < end {
+		value += int(text[tens+1] - '0')
+	}
+
+	return value, 0, end, true
+}
+
+// 查找文本中所有日期写法
+func scanDates(text []rune) []dateForm {
+	var forms []dateForm
+	next := 0 // 已识别的日期写法内部不再识别（如“1989年6月4日”中的“6月4日”）
+	for i := range text {
+		// 只从一个数的开头识别
+		if i < next || !isDateNumber(text[i]) || i > 0 && isDateNumber(text[i-1]) {
+			continue
+		}
+		for _, parse := range []func([]rune, int) (dateForm, bool){parseChineseDate, parseNumericDate, parseSquare} {
+			if form, ok := parse(text, i); ok {
+				forms = append(forms, form)
+				next = form.end
+				break
+			}
+		}
+	}
+
+	return forms
+}
+
+// 汉字写法：[年份年]月份月日期[日|号]，如“1989年6月4日”“5月35日”“五月三十五”
+func parseChineseDate(text []rune, i int) (dateForm, bool) {
+	form := dateForm{start: i}
+	value, digits, j, ok := readNumber(text, i)
+	if !ok {
+		return form, false
+	}
+	if j < len(text) && text[j] == '年' {
+		if digits != 2 && digits != 4 {
+			return form, false
+		}
+		form.year, form.yearDigits = value, digits
+		if value, digits, j, ok = readNumber(text, j+1); !ok {
+			return form, false
+		}
+	}
+	if j >= len(text) || text[j] != '月' || digits > 2 {
+		return form, false
+	}
+	form.month = value
+
+	day, digits, k, ok := readNumber(text, j+1)
+	if !ok || digits > 2 {
+		return form, false
+	}
+	form.day = day
+	if k < len(text) && (text[k] == '日' || text[k] == '号' || text[k] == '號') {
+		k++
+	}
+	form.end = k
+
+	return form, true
+}
+
+// 数字写法：月/日、年/月/日（分隔符相同），以及 8 位连写的年月日，如“6/4”“1989-06-04”“89.6.4”“19890604”
+// 不带年份时只接受斜杠，避免将“6.4分”“6-4”这类小数、比分误判为日期；紧邻字母或其他分隔数字的也不算日期，避免误判版本号、IP 地址等。
+func parseNumericDate(text []rune, i int) (dateForm, bool) {
+	form := dateForm{start: i}
+	if i > 0 && (isASCIILetter(text[i-1]) || isDateSeparator(text[i-1])) {
+		return form, false
+	}
+
+	var parts, digits []int
+	var sep rune
+	j := i
+	for {
+		value, n, end, ok := readNumber(text, j)
+		if !ok || n == 0 || len(parts) == 3 {
+			return form, false
+		}
+		parts, digits = append(parts, value), append(digits, n)
+		j = end
+		if j+1 < len(text) && isDateSeparator(text[j]) && (sep == 0 || text[j] == sep) && '0' <= text[j+1] && text[j+1] <= '9' {
+			sep = text[j]
+			j++
+			continue
+		}
+		break
+	}
+	if j < len(text) && isASCIILetter(text[j]) {
+		return form, false
+	}
+	form.end = j
+
+	switch len(parts) {
+	case 1:
+		if digits[0] != 8 {
+			return form, false
+		}
+		form.year, form.yearDigits = parts[0]/10000, 4
+		form.month, form.day = parts[0]/100%100, parts[0]%100
+	case 2:
+		if sep != '/' && sep != '／' || digits[0] > 2 || digits[1] > 2 {
+			return form, false
+		}
+		form.month, form.day = parts[0], parts[1]
+	case 3:
+		if digits[0] != 2 && digits[0] != 4 || digits[1] > 2 || digits[2] > 2 {
+			return form, false
+		}
+		form.year, form.yearDigits = parts[0], digits[0]
+		form.month, form.day = parts[1], parts[2]
+	}
+
+	return form, true
+}
+
+// 平方写法的后缀
+var squareSuffixes = [][]rune{[]rune("平方"), []rune("的平方"), []rune("²"), []rune("^2")}
+
+// 平方写法：“8平方”“八的平方”“8²”“8^2”，平方值为月日数字连写（如 6 月 4 日为 64）
+func parseSquare(text []rune, i int) (dateForm, bool) {
+	form := dateForm{start: i}
+	value, _, j, ok := readNumber(text, i)
+	if !ok || value == 0 {
+		return form, false
+	}
+
+	for _, suffix := range squareSuffixes {
+		end := j + len(suffix)
+		if end > len(text) || string(text[j:end]) != string(suffix) {
+			continue
+		}
+		if end < len(text) && strings.ContainsRune(areaUnits, text[end]) {
+			return form, false
+		}
+		form.end = end
+		form.square = value * value
+		return form, true
+	}
+
+	return form, false
+}
@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDateModel(t *testing.T) {
+	model := NewDateModel(NewDfaModel())
+	if err := model.SetDates(DateRule{Word: "六四", Month: 6, Day: 4}, DateRule{Year: 2008, Month: 3, Day: 14}); err != nil {
+		t.Fatal(err)
+	}
+	model.AddWord("敏感词")
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"6月4日", []string{"6月4日"}},
+		{"六月四号", []string{"六月四号"}},
+		{"1989年6月4日", []string{"1989年6月4日"}},
+		{"八九年六月四日", []string{"八九年六月四日"}},
+		{"5月35日", []string{"5月35日"}},
+		{"五月三十五", []string{"五月三十五"}},
+		{"4月65日", []string{"4月65日"}},
+		{"陆月肆日", []string{"陆月肆日"}},
+		{"记得6/4吗", []string{"6/4"}},
+		{"1989-06-04", []string{"1989-06-04"}},
+		{"19890604", []string{"19890604"}},
+		{"⑥/④", []string{"⑥/④"}},
+		{"89.6.4", []string{"89.6.4"}},
+		{"8平方", []string{"8平方"}},
+		{"八的平方", []string{"八的平方"}},
+		{"8²", []string{"8²"}},
+		{"2008年3月14日", []string{"2008年3月14日"}},
+		{"〇八年三月十四日", []string{"〇八年三月十四日"}},
+		{"3月14日", nil},      // 指定了年份的规则只命中带相同年份的写法
+		{"2009年3月14日", nil}, // 年份不同
+		{"6月5日", nil},       // 日期不同
+		{"16/45", nil},      // 紧邻其他数字
+		{"v6.4", nil},       // 版本号
+		{"10.6.4.1", nil},   // IP 地址
+		{"8平方米", nil},       // 面积
+		{"评分6.4分", nil},     // 不带年份时只接受斜杠
+		{"价格6.4元", nil},
+		{"比分6-4", nil},
+		{"6月4日的敏感词", []string{"6月4日", "敏感词"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, match := range model.FindAllMatches(tt.text) {
+			got = append(got, match.Text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindAllMatches(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+		if got := model.IsSensitive(tt.text); got != (len(tt.want) > 0) {
+			t.Errorf("IsSensitive(%q) = %v", tt.text, got)
+		}
+	}
+
+	if got, want := model.FindAll("5月35日与2008年3月14日"), []string{"六四", "2008年3月14日"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll = %v, want %v", got, want)
+	}
+	if got, want := model.Replace("纪念五月三十五", '*'), "纪念*****"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+}
+
+// 指定了年份的规则不按平方写法命中，“房间8平方”只是面积
+func TestDateModelYearSquare(t *testing.T) {
+	model := NewDateModel(NewDfaModel())
+	if err := model.SetDates(DateRule{Year: 1989, Month: 6, Day: 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{"房间8平方", "8²", "八的平方"} {
+		if got := model.FindAll(text); got != nil {
+			t.Errorf("FindAll(%q) = %v", text, got)
+		}
+	}
+	if got, want := model.FindAll("1989年6月4日"), []string{"1989年6月4日"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll = %v, want %v", got, want)
+	}
+}
+
+func TestDateModelAllowList(t *testing.T) {
+	allow := NewAcModel()
+	allow.AddWords("6/4英寸")
+	allow.Flush()
+
+	model := NewDateModel(NewDfaModel())
+	if err := model.SetDates(DateRule{Word: "六四", Month: 6, Day: 4}); err != nil {
+		t.Fatal(err)
+	}
+	model.SetAllowList(allow)
+
+	if model.IsSensitive("6/4英寸的屏幕") {
+		t.Error("IsSensitive inside allowed phrase = true")
+	}
+	if got, want := model.FindAll("6/4英寸，6/4"), []string{"六四"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll = %v, want %v", got, want)
+	}
+}
+
+func TestDateRuleInvalid(t *testing.T) {
+	model := NewDateModel(NewDfaModel())
+	for _, rule := range []DateRule{
+		{Month: 13, Day: 1},
+		{Month: 4, Day: 31},
+		{Year: 2023, Month: 2, Day: 29},
+		{Year: -1, Month: 1, Day: 1},
+	} {
+		if err := model.SetDates(rule); err == nil {
+			t.Errorf("SetDates(%+v): err = nil", rule)
+		}
+	}
+	if err := model.SetDates(DateRule{Month: 2, Day: 29}); err != nil {
+		t.Errorf("SetDates(2月29日): %v", err)
+	}
+}
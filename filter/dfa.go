@@ -1,27 +1,101 @@
 package filter
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
 // DFA 树节点结构
+// 已发布到快照中的节点不再修改，写操作会先复制路径上的节点（写时复制）。
 type dfaNode struct {
 	children map[rune]*dfaNode // 子节点
 	isLeaf   bool              // 是否为词尾
+	gen      uint64            // 创建该节点时的工作代数
+
+	pinyin atomic.Pointer[pinyinIndex] // 子节点的拼音索引，开启拼音匹配后按需构建
 }
 
 // DfaModel 是基于 DFA 的敏感词匹配器
-func newDfaNode() *dfaNode {
+func newDfaNode(gen uint64) *dfaNode {
 	return &dfaNode{
 		children: make(map[rune]*dfaNode),
 		isLeaf:   false,
+		gen:      gen,
 	}
 }
 
+// 复制节点，子节点仍与原节点共享
+func (n *dfaNode) clone(gen uint64) *dfaNode {
+	children := make(map[rune]*dfaNode, len(n.children))
+	for r, child := range n.children {
+		children[r] = child
+	}
+
+	return &dfaNode{
+		children: children,
+		isLeaf:   n.isLeaf,
+		gen:      gen,
+	}
+}
+
+// DfaModel 可安全地并发使用：查询只读取原子发布的只读快照，不加锁；
+// 增删词在工作树上进行，首次修改某个已发布的节点时先复制该节点，
+// 工作树在下一次查询时整体发布为新快照，批量加载词库时只需复制少量节点。
+//
+// 噪声跳过、间隔、字符等价、拼音、同音字、拆字、重复字符等模糊匹配均可通过 Set* 方法开启，
+// 以这些方式命中时，命中区间覆盖原文中的整段写法，命中词（Match.Word）仍为词库中的原词。
 type DfaModel struct {
-	root *dfaNode
+	matchConfig
+
+	mu   sync.Mutex // 串行化写操作与快照发布
+	root *dfaNode   // 工作树根节点，仅在持有 mu 时访问
+	gen  uint64     // 当前工作代数，代数等于 gen 的节点尚未发布，可直接修改
+
+	dirty    atomic.Bool             // 工作树是否有未发布的修改
+	snapshot atomic.Pointer[dfaNode] // 已发布的只读快照
+
+	skip            atomic.Pointer[func(r rune) bool] // 噪声字符判定函数
+	ignoreInvisible atomic.Bool                       // 是否忽略不可见字符
+	gap             atomic.Pointer[gapConfig]         // 间隔匹配配置
+	equivalences    atomic.Pointer[[]Equivalence]     // 字符等价关系
+	pinyinMode      atomic.Uint32                     // 拼音匹配方式
+	homophones      atomic.Int64                      // 允许以同音字代替的字数
+	split           atomic.Bool                       // 是否识别拆字写法
+	repeat          atomic.Int64                      // 一个字符最多匹配的连续重复次数
 }
 
 func NewDfaModel() *DfaModel {
-	return &DfaModel{
-		root: newDfaNode(),
+	m := &DfaModel{
+		root: newDfaNode(0),
+		gen:  1,
 	}
+	m.snapshot.Store(m.root)
+
+	return m
+}
+
+// 获取最新的只读快照，有未发布的修改时先发布
+func (m *DfaModel) current() *dfaNode {
+	if m.dirty.Load() {
+		m.mu.Lock()
+		if m.dirty.Load() {
+			m.snapshot.Store(m.root)
+			m.gen++ // 已发布的节点此后只能复制修改
+			m.dirty.Store(false)
+		}
+		m.mu.Unlock()
+	}
+
+	return m.snapshot.Load()
+}
+
+// 返回可修改的节点，已发布的节点会被复制，调用方需持有 mu
+func (m *DfaModel) mutable(n *dfaNode) *dfaNode {
+	if n.gen == m.gen {
+		return n
+	}
+
+	return n.clone(m.gen)
 }
 
 // 添加多个词
@@ -37,20 +111,25 @@ func (m *DfaModel) AddWord(word string) {
 		return
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.root = m.mutable(m.root)
 	now := m.root
-	runes := []rune(word)
 
-	for _, r := range runes {
-		if next, ok := now.children[r]; ok {
-			now = next
+	for _, r := range word {
+		next, ok := now.children[r]
+		if ok {
+			next = m.mutable(next)
 		} else {
-			next = newDfaNode()
-			now.children[r] = next
-			now = next
+			next = newDfaNode(m.gen)
 		}
+		now.children[r] = next
+		now = next
 	}
 
 	now.isLeaf = true
+	m.dirty.Store(true)
 }
 
 // 删除多个词
@@ -60,41 +139,49 @@ func (m *DfaModel) DelWords(words ...string) {
 	}
 }
 
-// 删除单个词（仅支持叶子节点剪枝）
+// 删除单个词，并剪除不再通向任何词尾的分支
 func (m *DfaModel) DelWord(word string) {
 	if word == "" {
 		return
 	}
 
-	var lastLeaf *dfaNode
-	var lastLeafNextRune rune
-	now := m.root
-	runes := []rune(word)
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
+	// 先确认词存在，避免无谓地复制节点
+	runes := []rune(word)
+	now := m.root
 	for _, r := range runes {
-		if next, ok := now.children[r]; !ok {
+		next, ok := now.children[r]
+		if !ok {
 			return
-		} else {
-			if now.isLeaf {
-				lastLeaf = now
-				lastLeafNextRune = r
-			}
-			now = next
 		}
+		now = next
 	}
-
-	// 确保找到的词确实是叶子节点
 	if !now.isLeaf {
 		return
 	}
 
-	if lastLeaf != nil {
-		// 没有其他分支，删除从 lastLeaf 到目标节点的路径
-		delete(lastLeaf.children, lastLeafNextRune)
-	} else {
-		// 有其他分支，只取消叶子标记
-		now.isLeaf = false
+	m.root = m.mutable(m.root)
+	path := make([]*dfaNode, 0, len(runes)+1)
+	path = append(path, m.root)
+	for _, r := range runes {
+		next := m.mutable(path[len(path)-1].children[r])
+		path[len(path)-1].children[r] = next
+		path = append(path, next)
+	}
+
+	path[len(path)-1].isLeaf = false
+
+	// 自底向上剪除既不是词尾也没有子节点的节点
+	for i := len(runes); i > 0; i-- {
+		if node := path[i]; node.isLeaf || len(node.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, runes[i-1])
 	}
+
+	m.dirty.Store(true)
 }
 
 // 监听新增和删除通道
@@ -112,190 +199,81 @@ func (m *DfaModel) Listen(addChan, delChan <-chan string) {
 	}()
 }
 
-// 查找文本中所有敏感词
-func (m *DfaModel) FindAll(text string) []string {
-	var matches []string // stores words that match in dict
-	var found bool       // if current rune in node's map
-	var now *dfaNode     // current node
-
-	start := 0
-	parent := m.root
-	runes := []rune(text)
-	length := len(runes)
-
-	for pos := 0; pos < length; pos++ {
-		now, found = parent.children[runes[pos]]
-
-		if !found {
-			parent = m.root
-			pos = start
-			start++
-			continue
-		}
-
-		if now.isLeaf && start <= pos {
-			matches = append(matches, string(runes[start:pos+1]))
-		}
+// Flush DFA 的增删词返回时已生效，无需等待
+func (m *DfaModel) Flush() {}
 
-		if pos == length-1 {
-			parent = m.root
-			pos = start
-			start++
-			continue
-		}
+// 扫描文本，对每个命中回调 fn(h)，区间为左闭右开的 rune 下标
+// 命中按起始位置依次回调，fn 返回 false 时停止扫描
+func (m *DfaModel) scan(runes []rune, fn func(h hit) bool) {
+	root := m.current()
 
-		parent = now
+	// 设置了噪声跳过、间隔匹配、字符等价、拼音、同音字、拆字或重复字符匹配时使用带跳过规则的遍历
+	skip, gap, equivalences := m.skipFunc(), m.gap.Load(), m.equivalenceList()
+	pinyin, homophones, split, repeat := PinyinMode(m.pinyinMode.Load()), int(m.homophones.Load()), m.split.Load(), int(m.repeat.Load())
+	if skip != nil || (gap != nil && gap.max > 0) || len(equivalences) > 0 || pinyin != PinyinOff || homophones != 0 || split || repeat != 0 {
+		newDfaWalker(runes, skip, gap, equivalences, pinyin, homophones, split, repeat, fn).walk(root)
+		return
 	}
 
-	var res []string
-	set := make(map[string]struct{})
-
-	for _, word := range matches {
-		if _, ok := set[word]; !ok {
-			set[word] = struct{}{}
-			res = append(res, word)
+	for start := range runes {
+		now := root
+		for pos := start; pos < len(runes); pos++ {
+			next, ok := now.children[runes[pos]]
+			if !ok {
+				break
+			}
+			if next.isLeaf && !fn(hit{start: start, end: pos + 1}) {
+				return
+			}
+			now = next
 		}
 	}
-
-	return res
 }
 
-// 查找所有敏感词及其出现次数
-func (m *DfaModel) FindAllCount(text string) map[string]int {
-	res := make(map[string]int)
-	var found bool
-	var now *dfaNode
-
-	start := 0
-	parent := m.root
+// 查找文本中所有敏感词命中（按文本顺序）
+func (m *DfaModel) FindAllMatches(text string) []Match {
+	var hits []hit
 	runes := []rune(text)
-	length := len(runes)
 
-	for pos := 0; pos < length; pos++ {
-		now, found = parent.children[runes[pos]]
+	m.scan(runes, func(h hit) bool {
+		hits = append(hits, h)
+		return true
+	})
 
-		if !found {
-			parent = m.root
-			pos = start
-			start++
-			continue
-		}
-
-		if now.isLeaf && start <= pos {
-			res[string(runes[start:pos+1])]++
-		}
-
-		if pos == length-1 {
-			parent = m.root
-			pos = start
-			start++
-			continue
-		}
+	return m.newMatches(text, runes, hits)
+}
 
-		parent = now
-	}
+// 查找文本中所有敏感词
+func (m *DfaModel) FindAll(text string) []string {
+	return findAll(m.FindAllMatches(text))
+}
 
-	return res
+// 查找所有敏感词及其出现次数
+func (m *DfaModel) FindAllCount(text string) map[string]int {
+	return findAllCount(m.FindAllMatches(text))
 }
 
-// 查找一个敏感词（命中第一个即返回）
+// 查找一个敏感词（返回文本中第一个命中）
 func (m *DfaModel) FindOne(text string) string {
-	var found bool
-	var now *dfaNode
-
-	start := 0
-	parent := m.root
-	runes := []rune(text)
-	length := len(runes)
-
-	for pos := 0; pos < length; pos++ {
-		now, found = parent.children[runes[pos]]
-
-		if !found || (!now.isLeaf && pos == length-1) {
-			parent = m.root
-			pos = start
-			start++
-			continue
-		}
-
-		if now.isLeaf && start <= pos {
-			return string(runes[start : pos+1])
-		}
-
-		parent = now
-	}
-
-	return ""
+	return findOne(m.FindAllMatches(text))
 }
 
 // 判断文本中是否包含敏感词
 func (m *DfaModel) IsSensitive(text string) bool {
-	return m.FindOne(text) != ""
+	return m.isSensitive(text, m.scan)
 }
 
 // 将敏感词替换为指定字符（如 *）
 func (m *DfaModel) Replace(text string, repl rune) string {
-	var found bool
-	var now *dfaNode
-
-	start := 0
-	parent := m.root
-	runes := []rune(text)
-	length := len(runes)
-
-	for pos := 0; pos < length; pos++ {
-		now, found = parent.children[runes[pos]]
-
-		if !found || (!now.isLeaf && pos == length-1) {
-			parent = m.root
-			pos = start
-			start++
-			continue
-		}
-
-		if now.isLeaf && start <= pos {
-			for i := start; i <= pos; i++ {
-				runes[i] = repl
-			}
-		}
-
-		parent = now
-	}
+	return replaceMatches(text, m.FindAllMatches(text), repl)
+}
 
-	return string(runes)
+// 使用回调函数的返回值替换每个敏感词，重叠的命中会先合并为一段
+func (m *DfaModel) ReplaceFunc(text string, fn func(Match) string) string {
+	return ReplaceMatchesFunc(text, m.FindAllMatches(text), fn)
 }
 
 // 将敏感词从文本中完全移除
 func (m *DfaModel) Remove(text string) string {
-	var found bool
-	var now *dfaNode
-
-	start := 0 // 从文本的第几个文字开始匹配
-	parent := m.root
-	runes := []rune(text)
-	length := len(runes)
-	filtered := make([]rune, 0, length)
-
-	for pos := 0; pos < length; pos++ {
-		now, found = parent.children[runes[pos]]
-
-		if !found || (!now.isLeaf && pos == length-1) {
-			filtered = append(filtered, runes[start])
-			parent = m.root
-			pos = start
-			start++
-			continue
-		}
-
-		if now.isLeaf {
-			start = pos + 1
-			parent = m.root
-		} else {
-			parent = now
-		}
-	}
-
-	filtered = append(filtered, runes[start:]...)
-
-	return string(filtered)
+	return removeMatches(text, m.FindAllMatches(text))
 }
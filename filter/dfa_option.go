@@ -0,0 +1,158 @@
+package filter
+
+import (
+	"github.com/zmexing/go-sensitive-word/normalize"
+	"unicode"
+)
+
+// DefaultSkip 默认的噪声字符集合：空白、标点与符号（含大部分 emoji）
+// 可配合 DfaModel.SetSkip 使用，使“法 轮-功”“傻*逼”等插入噪声的写法仍能命中。
+func DefaultSkip(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+// SetSkip 设置噪声字符判定函数，传入 nil 关闭噪声跳过（默认）
+// 匹配过程中位于命中词内部的噪声字符会被跳过，噪声字符本身是某个词的一部分时优先按原字符匹配。
+func (m *DfaModel) SetSkip(skip func(r rune) bool) {
+	if skip == nil {
+		m.skip.Store(nil)
+		return
+	}
+	m.skip.Store(&skip)
+}
+
+// SetIgnoreInvisible 设置是否忽略命中词内部的不可见字符（零宽字符、软连字符、变体选择符、标签字符等）
+// 开启后“马\u200b斯\u200b克”仍会命中“马斯克”，命中区间覆盖其中的不可见字符，Replace、Remove 作用于原文。
+func (m *DfaModel) SetIgnoreInvisible(ignore bool) {
+	m.ignoreInvisible.Store(ignore)
+}
+
+// 返回当前的噪声字符判定函数，包括忽略不可见字符的设置，均未设置时返回 nil
+func (m *DfaModel) skipFunc() func(r rune) bool {
+	var skip func(r rune) bool
+	if p := m.skip.Load(); p != nil {
+		skip = *p
+	}
+	if !m.ignoreInvisible.Load() {
+		return skip
+	}
+	if skip == nil {
+		return normalize.IsInvisible
+	}
+
+	return func(r rune) bool {
+		return normalize.IsInvisible(r) || skip(r)
+	}
+}
+
+// DfaModel 的间隔匹配配置
+type gapConfig struct {
+	global int            // 全局最大间隔
+	words  map[string]int // 逐词设置的最大间隔，优先于全局设置
+	max    int            // 遍历时允许的最大间隔，即以上设置中的最大值
+}
+
+// 返回词允许的最大间隔
+func (c *gapConfig) limit(word string) int {
+	if gap, ok := c.words[word]; ok {
+		return gap
+	}
+	return c.global
+}
+
+// SetMaxGap 设置全局最大间隔：词中相邻两个字符之间最多允许插入 gap 个任意字符，默认 0 表示必须相邻
+// 例如 gap 为 2 时“习某某近平”仍会命中“习近平”，命中区间覆盖包括间隔字符在内的整段原文。
+// 间隔过大容易误报，建议只对较长或较敏感的词通过 SetWordMaxGap 单独设置。
+func (m *DfaModel) SetMaxGap(gap int) {
+	m.updateGap(func(c *gapConfig) {
+		c.global = max(gap, 0)
+	})
+}
+
+// SetWordMaxGap 为指定的词单独设置最大间隔，优先于 SetMaxGap 的全局设置，gap 为 0 时该词必须相邻
+func (m *DfaModel) SetWordMaxGap(gap int, words ...string) {
+	m.updateGap(func(c *gapConfig) {
+		for _, word := range words {
+			c.words[word] = max(gap, 0)
+		}
+	})
+}
+
+// 复制当前的间隔配置并修改后发布，查询中使用的旧配置不受影响
+func (m *DfaModel) updateGap(update func(c *gapConfig)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := &gapConfig{words: make(map[string]int)}
+	if old := m.gap.Load(); old != nil {
+		c.global = old.global
+		for word, gap := range old.words {
+			c.words[word] = gap
+		}
+	}
+	update(c)
+
+	c.max = c.global
+	for _, gap := range c.words {
+		c.max = max(c.max, gap)
+	}
+	m.gap.Store(c)
+}
+
+// SetEquivalences 设置匹配时使用的字符等价关系，如 TraditionalSimplified，不传参数时关闭（默认）
+// 输入字符本身或其等价字符与词中字符相同即可匹配。
+func (m *DfaModel) SetEquivalences(equivalences ...Equivalence) {
+	if len(equivalences) == 0 {
+		m.equivalences.Store(nil)
+		return
+	}
+	m.equivalences.Store(&equivalences)
+}
+
+// 返回当前的字符等价关系，未设置时返回 nil
+func (m *DfaModel) equivalenceList() []Equivalence {
+	if equivalences := m.equivalences.Load(); equivalences != nil {
+		return *equivalences
+	}
+	return nil
+}
+
+// SetPinyin 设置拼音匹配方式，默认 PinyinOff
+// 开启后文本中的拉丁字母会按词中汉字的拼音匹配（考虑多音字），可与汉字混写，如“法lun功”；
+// 拼音片段须与前后的字母分开，避免在英文单词内部误报；音节之间的空格需由 SetSkip 跳过，如“fa lun gong”。
+func (m *DfaModel) SetPinyin(mode PinyinMode) {
+	m.pinyinMode.Store(uint32(mode))
+}
+
+// HomophoneAll 同音字匹配时不限制以同音字代替的字数，可用于 DfaModel.SetHomophone
+const HomophoneAll = -1
+
+// SetHomophone 设置同音字匹配：输入字符按不带声调的拼音与词中字符比较，如“蒸纸”命中“政治”
+// n 为每个命中中最多允许以同音字代替的字数，HomophoneAll 表示不限，0 表示关闭（默认）。
+// 单个汉字不单独以同音字命中；两个字的词两个字都被代替时，原文全部是常用字（如“是个”“故事”）不算命中。
+func (m *DfaModel) SetHomophone(n int) {
+	if n < 0 {
+		n = HomophoneAll
+	}
+	m.homophones.Store(int64(n))
+}
+
+// SetSplitCharacters 设置是否识别拆字写法，默认关闭
+// 开启后输入中相邻的两个部件可以合成内置拆字表中的字参与匹配，如“氵去轮功”命中“法轮功”、“弓长三”命中“张三”，
+// 偏旁也可以写作独立字形（如“水去”）。
+func (m *DfaModel) SetSplitCharacters(split bool) {
+	m.split.Store(split)
+}
+
+// RepeatAll 重复字符匹配时不限制连续重复的次数，可用于 DfaModel.SetMaxRepeat
+const RepeatAll = -1
+
+// SetMaxRepeat 设置重复字符匹配：词中的一个字符可以匹配输入中连续重复的同一个字符，如“傻傻傻逼逼”“fuuuuuck”
+// n 为一个字符最多匹配的连续字符数，RepeatAll 表示不限，0 表示关闭（默认）。
+// 词中本身含有的重复字符（如“哈哈”）仍按原样匹配。
+func (m *DfaModel) SetMaxRepeat(n int) {
+	if n < 0 {
+		n = RepeatAll
+	}
+	m.repeat.Store(int64(n))
+}
@@ -0,0 +1,393 @@
+package filter
+
+import (
+	"github.com/zmexing/go-sensitive-word/normalize"
+	"reflect"
+	"testing"
+)
+
+func TestDfaDelWord(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("ab", "abcd", "abce", "xyz")
+
+	// 快照发布后再删除，已发布的快照不受影响
+	before := model.current()
+	model.DelWords("abcd", "xyz", "missing")
+
+	if got, want := model.FindAll("abcd abce xyz"), []string{"ab", "abce"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll = %v, want %v", got, want)
+	}
+	if _, ok := model.current().children['x']; ok {
+		t.Error("branch of deleted word xyz was not pruned")
+	}
+	if _, ok := before.children['x']; !ok {
+		t.Error("published snapshot was modified")
+	}
+
+	model.DelWord("ab")
+	if got, want := model.FindAll("abcd abce"), []string{"abce"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll after deleting prefix word = %v, want %v", got, want)
+	}
+}
+
+func TestDfaSkip(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("法轮功", "傻逼", "a*b")
+	model.SetSkip(DefaultSkip)
+
+	text := "法 轮-功，傻*逼！a*b"
+	want := []Match{
+		{Word: "法轮功", Text: "法 轮-功", Start: 0, End: 5, ByteStart: 0, ByteEnd: 11, UTF16Start: 0, UTF16End: 5},
+		{Word: "傻逼", Text: "傻*逼", Start: 6, End: 9, ByteStart: 14, ByteEnd: 21, UTF16Start: 6, UTF16End: 9},
+		{Word: "a*b", Text: "a*b", Start: 10, End: 13, ByteStart: 24, ByteEnd: 27, UTF16Start: 10, UTF16End: 13},
+	}
+	if got := model.FindAllMatches(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllMatches = %+v, want %+v", got, want)
+	}
+	if got, want := model.Replace(text, '*'), "*****，***！***"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+
+	// 噪声不会出现在命中的首尾
+	if got := model.FindAllMatches(" 傻逼 "); len(got) != 1 || got[0].Start != 1 || got[0].End != 3 {
+		t.Errorf("FindAllMatches with surrounding noise = %+v", got)
+	}
+
+	model.SetSkip(nil)
+	if model.IsSensitive("法 轮-功") {
+		t.Error("IsSensitive matched noisy text with skipping disabled")
+	}
+}
+
+func TestDfaMaxGap(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("习近平", "法轮功", "ab")
+	model.SetMaxGap(2)
+
+	text := "习某某近平与法X轮X功，a12b"
+	want := []Match{
+		{Word: "习近平", Text: "习某某近平", Start: 0, End: 5, ByteStart: 0, ByteEnd: 15, UTF16Start: 0, UTF16End: 5},
+		{Word: "法轮功", Text: "法X轮X功", Start: 6, End: 11, ByteStart: 18, ByteEnd: 29, UTF16Start: 6, UTF16End: 11},
+		{Word: "ab", Text: "a12b", Start: 12, End: 16, ByteStart: 32, ByteEnd: 36, UTF16Start: 12, UTF16End: 16},
+	}
+	if got := model.FindAllMatches(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllMatches = %+v, want %+v", got, want)
+	}
+	if got, want := model.Replace(text, '*'), "*****与*****，****"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+	if model.IsSensitive("习某某某近平") {
+		t.Error("IsSensitive matched a gap longer than the limit")
+	}
+
+	// 逐词设置优先于全局设置
+	model.SetWordMaxGap(0, "ab")
+	model.SetWordMaxGap(3, "习近平")
+	if got, want := model.FindAll("a1b 习某某某近平"), []string{"习近平"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll with per-word gaps = %v, want %v", got, want)
+	}
+
+	// 可经由不同间隔到达的同一命中只返回一次
+	model.SetMaxGap(1)
+	model.AddWord("aba")
+	if got := model.FindAllCount("abba"); got["aba"] != 1 {
+		t.Errorf("FindAllCount = %v, want aba once", got)
+	}
+
+	model.SetMaxGap(0)
+	model.SetWordMaxGap(0, "习近平")
+	if model.IsSensitive("习某近平") {
+		t.Error("IsSensitive matched a gap with gaps disabled")
+	}
+}
+
+func TestDfaIgnoreInvisible(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("马斯克", "ab")
+	model.SetIgnoreInvisible(true)
+
+	// 零宽空格、零宽连接符、词连接符、软连字符、变体选择符与标签字符
+	text := "\u200b马\u200b\u200d斯\u2060克\u00ad a\ufe0fb\U000E0061"
+	if got, want := model.FindAll(text), []string{"马斯克", "ab"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll = %v, want %v", got, want)
+	}
+	if got, want := model.Replace(text, '*'), "\u200b******\u00ad ***\U000E0061"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+
+	// 与噪声跳过同时生效
+	model.SetSkip(DefaultSkip)
+	if !model.IsSensitive("马 斯\u200b克") {
+		t.Error("IsSensitive with skip and invisible characters = false")
+	}
+
+	model.SetSkip(nil)
+	model.SetIgnoreInvisible(false)
+	if model.IsSensitive("马\u200b斯克") {
+		t.Error("IsSensitive with invisible characters not ignored = true")
+	}
+}
+
+func TestDfaTraditionalSimplified(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("发财", "头发", "後門")
+	model.SetEquivalences(TraditionalSimplified)
+
+	text := "恭喜發財，剪頭髮，走后门"
+	want := []Match{
+		{Word: "发财", Text: "發財", Start: 2, End: 4, ByteStart: 6, ByteEnd: 12, UTF16Start: 2, UTF16End: 4},
+		{Word: "头发", Text: "頭髮", Start: 6, End: 8, ByteStart: 18, ByteEnd: 24, UTF16Start: 6, UTF16End: 8},
+		{Word: "後門", Text: "后门", Start: 10, End: 12, ByteStart: 30, ByteEnd: 36, UTF16Start: 10, UTF16End: 12},
+	}
+	if got := model.FindAllMatches(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllMatches = %+v, want %+v", got, want)
+	}
+	if got, want := model.Replace(text, '*'), "恭喜**，剪**，走**"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+
+	// 与间隔匹配同时生效
+	model.SetMaxGap(1)
+	if got, want := model.FindAll("發X財"), []string{"发财"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll with gap = %v, want %v", got, want)
+	}
+
+	model.SetEquivalences()
+	if model.IsSensitive("發財") {
+		t.Error("IsSensitive with equivalences disabled = true")
+	}
+}
+
+func TestDfaPinyin(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("法轮功", "重庆", "台独", "澳")
+	model.SetSkip(DefaultSkip)
+	model.SetPinyin(PinyinAll)
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"falungong", []string{"法轮功"}},
+		{"fa lun gong", []string{"法轮功"}},
+		{"FLG好", []string{"法轮功"}},
+		{"法lun功", []string{"法轮功"}},
+		{"f-l-g", []string{"法轮功"}},
+		{"chongqing", []string{"重庆"}}, // 多音字
+		{"zhongqing", []string{"重庆"}},
+		{"zhq", []string{"重庆"}},
+		{"cq", nil},            // 全部以首字母匹配时至少需要 3 个字母
+		{"c庆", []string{"重庆"}}, // 首字母与汉字混用
+		{"flag", nil},          // 英文单词内部
+		{"xfalungong", nil},    // 前面紧跟字母
+		{"falungongx", nil},    // 后面紧跟字母
+		{"ao", nil},            // 单个汉字不单独以拼音命中
+		{"tdu", nil},           // 同一段字母中全拼与首字母不混用
+		{"tai du", []string{"台独"}},
+	}
+	for _, tt := range tests {
+		if got := model.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindAll(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	// 英文短词不以首字母命中，罕用读音与零声母音节不参与匹配
+	model.AddWords("吾尔", "美幼", "共狗", "邪恶", "偷荤二奶", "干女也", "哈克")
+	for _, text := range []string{"we are my gg friends", "she", "then", "any", "take"} {
+		if got := model.FindAll(text); got != nil {
+			t.Errorf("FindAll(%q) = %v", text, got)
+		}
+	}
+
+	// 音节之间的空格需由 Skip 跳过
+	model.SetSkip(nil)
+	if got := model.FindAll("fa lun gong"); got != nil {
+		t.Errorf("FindAll without skip = %v", got)
+	}
+	model.SetSkip(DefaultSkip)
+
+	want := []Match{{Word: "法轮功", Text: "fa lun gong", Start: 2, End: 13, ByteStart: 6, ByteEnd: 17, UTF16Start: 2, UTF16End: 13}}
+	if got := model.FindAllMatches("练习fa lun gong"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllMatches = %+v, want %+v", got, want)
+	}
+
+	model.SetPinyin(PinyinFull)
+	if model.IsSensitive("flg") {
+		t.Error("IsSensitive with PinyinFull = true for initials")
+	}
+	model.SetPinyin(PinyinInitial)
+	if model.IsSensitive("falungong") || !model.IsSensitive("flg") {
+		t.Error("IsSensitive with PinyinInitial mismatched")
+	}
+	model.SetPinyin(PinyinOff)
+	if model.IsSensitive("falungong") {
+		t.Error("IsSensitive with pinyin disabled = true")
+	}
+}
+
+func TestDfaHomophone(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("政治", "法轮功", "澳", "石戈", "股市")
+
+	tests := []struct {
+		limit int
+		text  string
+		want  []string
+	}{
+		{1, "政纸", []string{"政治"}},
+		{1, "发轮功", []string{"法轮功"}},
+		{1, "蒸纸", nil}, // 超出允许代替的字数
+		{2, "蒸纸", []string{"政治"}},
+		{HomophoneAll, "发伦攻", []string{"法轮功"}},
+		{HomophoneAll, "奥", nil}, // 单个汉字不单独以同音字命中
+		{HomophoneAll, "长度", nil},
+		{HomophoneAll, "今天是个好日子", nil}, // 两个字都被代替且原文都是常用字
+		{HomophoneAll, "讲个故事", nil},
+		{HomophoneAll, "讲个故市", []string{"股市"}}, // 只代替了一个字
+		{0, "政纸", nil},
+	}
+	for _, tt := range tests {
+		model.SetHomophone(tt.limit)
+		if got := model.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SetHomophone(%d): FindAll(%q) = %v, want %v", tt.limit, tt.text, got, tt.want)
+		}
+	}
+
+	model.SetHomophone(1)
+	want := []Match{{Word: "政治", Text: "正治", Start: 2, End: 4, ByteStart: 6, ByteEnd: 12, UTF16Start: 2, UTF16End: 4}}
+	if got := model.FindAllMatches("不谈正治"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllMatches = %+v, want %+v", got, want)
+	}
+}
+
+func TestDfaSimilarGlyph(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("自己", "周末", "习近平")
+	model.SetEquivalences(SimilarGlyph)
+
+	text := "做自已，过周未，刁近平"
+	want := []Match{
+		{Word: "自己", Text: "自已", Start: 1, End: 3, ByteStart: 3, ByteEnd: 9, UTF16Start: 1, UTF16End: 3},
+		{Word: "周末", Text: "周未", Start: 5, End: 7, ByteStart: 15, ByteEnd: 21, UTF16Start: 5, UTF16End: 7},
+		{Word: "习近平", Text: "刁近平", Start: 8, End: 11, ByteStart: 24, ByteEnd: 33, UTF16Start: 8, UTF16End: 11},
+	}
+	if got := model.FindAllMatches(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllMatches = %+v, want %+v", got, want)
+	}
+}
+
+func TestDfaSplitCharacters(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("法轮功", "张三", "习近平")
+	model.SetSplitCharacters(true)
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"氵去轮功", []string{"法轮功"}},
+		{"水去轮功", []string{"法轮功"}}, // 偏旁写作独立字形
+		{"氵去车仑工力", []string{"法轮功"}},
+		{"弓长三", []string{"张三"}},
+		{"习辶斤平", []string{"习近平"}},
+		{"氵 去轮功", nil},
+	}
+	for _, tt := range tests {
+		if got := model.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindAll(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	want := []Match{{Word: "张三", Text: "弓长三", Start: 2, End: 5, ByteStart: 6, ByteEnd: 15, UTF16Start: 2, UTF16End: 5}}
+	if got := model.FindAllMatches("我是弓长三"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllMatches = %+v, want %+v", got, want)
+	}
+	if got, want := model.Replace("我是弓长三", '*'), "我是***"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+
+	model.SetSplitCharacters(false)
+	if model.IsSensitive("弓长三") {
+		t.Error("IsSensitive with split characters disabled = true")
+	}
+}
+
+func TestDfaLeetspeak(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("shit", "ass", "fuck", "fag", "sb", "1984")
+	model.SetEquivalences(Leetspeak)
+	inner := NewDfaModel()
+	inner.SetEquivalences(Leetspeak)
+	folded := NewNormalizedModel(inner, normalize.NFKCFold)
+	folded.AddWords("shit", "sb")
+
+	tests := []struct {
+		text string
+		fold bool // 是否同时使用 normalize.NFKCFold
+		want []string
+	}{
+		{"sh1t", false, []string{"shit"}},
+		{"a$$", false, []string{"ass"}},
+		{"f@ck", false, []string{"fuck"}},
+		{"f@g", false, []string{"fag"}},
+		{"5b", false, []string{"sb"}},
+		{"1984", false, []string{"1984"}}, // 数字仍按原字符匹配
+		{"5B", false, nil},                // 字母不做大小写折叠
+		{"SH1T", false, nil},
+		{"5B", true, []string{"sb"}},
+		{"SH1T", true, []string{"shit"}},
+	}
+	for _, tt := range tests {
+		var m Filter = model
+		if tt.fold {
+			m = folded
+		}
+		if got := m.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindAll(%q, fold=%v) = %v, want %v", tt.text, tt.fold, got, tt.want)
+		}
+	}
+
+	// 命中位置对应原文
+	want := []Match{{Word: "fuck", Text: "f@ck", Start: 3, End: 7, ByteStart: 3, ByteEnd: 7, UTF16Start: 3, UTF16End: 7}}
+	if got := model.FindAllMatches("oh f@ck"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllMatches = %+v, want %+v", got, want)
+	}
+
+	// 自定义替换类
+	table := LeetspeakTable()
+	table['#'] = []rune{'h'}
+	model.SetEquivalences(table.Equivalence())
+	if got, want := model.FindAll("s#!t"), []string{"shit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll with custom table = %v, want %v", got, want)
+	}
+}
+
+func TestDfaMaxRepeat(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("傻逼", "fuck", "哈哈")
+
+	tests := []struct {
+		limit int
+		text  string
+		want  []Match
+	}{
+		{RepeatAll, "傻傻傻逼逼！", []Match{{Word: "傻逼", Text: "傻傻傻逼逼", Start: 0, End: 5, ByteStart: 0, ByteEnd: 15, UTF16Start: 0, UTF16End: 5}}},
+		{RepeatAll, "fuuuuuck", []Match{{Word: "fuck", Text: "fuuuuuck", Start: 0, End: 8, ByteStart: 0, ByteEnd: 8, UTF16Start: 0, UTF16End: 8}}},
+		{RepeatAll, "哈哈哈哈", []Match{{Word: "哈哈", Text: "哈哈哈哈", Start: 0, End: 4, ByteStart: 0, ByteEnd: 12, UTF16Start: 0, UTF16End: 4}}},
+		{3, "fuuuck", []Match{{Word: "fuck", Text: "fuuuck", Start: 0, End: 6, ByteStart: 0, ByteEnd: 6, UTF16Start: 0, UTF16End: 6}}},
+		{3, "fuuuuck", nil}, // 超出重复次数上限
+		{3, "傻逼逼逼逼", []Match{{Word: "傻逼", Text: "傻逼逼逼", Start: 0, End: 4, ByteStart: 0, ByteEnd: 12, UTF16Start: 0, UTF16End: 4}}},
+		{0, "fuuck", nil},
+	}
+	for _, tt := range tests {
+		model.SetMaxRepeat(tt.limit)
+		if got := model.FindAllMatches(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SetMaxRepeat(%d): FindAllMatches(%q) = %+v, want %+v", tt.limit, tt.text, got, tt.want)
+		}
+	}
+
+	model.SetMaxRepeat(RepeatAll)
+	if got, want := model.Replace("你个傻傻逼逼", '*'), "你个****"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,397 @@
+package filter
+
+// dfaWalker 在 DFA 树上做带跳过规则的深度优先遍历（跳过噪声字符、允许间隔、字符等价、拼音）
+// 同一起始位置可能经由不同的跳过方式到达同一节点，因此需要对状态与命中去重。
+type dfaWalker struct {
+	runes        []rune
+	skip         func(r rune) bool
+	gap          *gapConfig
+	equivalences []Equivalence
+	pinyin       PinyinMode
+	homophones   int  // 允许以同音字代替的字数，0 表示关闭，小于 0 表示不限
+	split        bool // 是否识别拆字写法
+	repeat       int  // 一个字符最多匹配的连续重复次数，0 表示关闭，小于 0 表示不限
+	fn           func(h hit) bool
+
+	start     int
+	path      []rune                  // 当前候选词已匹配的字符，即词库中的原词
+	subs      int                     // 路径上以等价字符、拼音或同音字代替输入字符的次数
+	spelled   int                     // 路径上按读音（拼音或同音字）匹配的字符数
+	replaced  int                     // 路径上以同音字代替的字数
+	initials  int                     // 路径上以首字母匹配的字数
+	letters   int                     // 以首字母匹配时消耗的字母数
+	pinyinEnd int                     // 上一步以拼音匹配时拼音片段的结束位置，否则为 -1
+	lastKind  stepKind                // 上一步的匹配方式
+	visited   map[dfaWalkState]bool   // 已遍历的状态，仅在允许间隔时使用
+	emitted   map[dfaWalkHit]struct{} // 当前起始位置已回调的命中，仅在允许间隔或重复时使用
+}
+
+// 遍历状态：所在节点、文本位置、当前间隔长度与路径上的最大间隔
+type dfaWalkState struct {
+	node   *dfaNode
+	pos    int
+	gap    int
+	widest int
+}
+
+// 遍历中每一步的匹配方式
+type stepKind uint8
+
+const (
+	stepChar          stepKind = iota // 原字符或等价字符
+	stepPinyinFull                    // 全拼
+	stepPinyinInitial                 // 首字母
+	stepHomophone                     // 同音字
+	stepSplit                         // 拆字，两个部件合成一个字
+)
+
+// 全部以首字母匹配的命中至少需要的字母数
+const minInitialLetters = 3
+
+// 命中去重键：同一起始位置下的结束位置与词尾节点
+type dfaWalkHit struct {
+	end  int
+	node *dfaNode
+}
+
+func newDfaWalker(runes []rune, skip func(r rune) bool, gap *gapConfig, equivalences []Equivalence, pinyin PinyinMode, homophones int, split bool, repeat int, fn func(h hit) bool) *dfaWalker {
+	w := &dfaWalker{
+		runes:        runes,
+		skip:         skip,
+		equivalences: equivalences,
+		pinyin:       pinyin,
+		homophones:   homophones,
+		split:        split,
+		repeat:       repeat,
+		fn:           fn,
+		pinyinEnd:    -1,
+	}
+	if gap != nil && gap.max > 0 {
+		w.gap = gap
+		w.visited = make(map[dfaWalkState]bool)
+	}
+	if w.gap != nil || repeat != 0 {
+		w.emitted = make(map[dfaWalkHit]struct{})
+	}
+
+	return w
+}
+
+// 遍历所有起始位置，fn 返回 false 时停止
+// 允许重复时，一段重复字符只从段首起匹配；限制了重复次数时，超出上限的长段每隔上限个字符再作为一次起始位置。
+func (w *dfaWalker) walk(root *dfaNode) {
+	run := 0 // 当前重复字符段的起始位置
+	for start := range w.runes {
+		if start == 0 || w.runes[start] != w.runes[start-1] {
+			run = start
+		}
+		if w.repeat != 0 && start > run && (w.repeat < 0 || (start-run)%w.repeat != 0) {
+			continue
+		}
+
+		w.start = start
+		w.path = w.path[:0]
+		if w.gap != nil {
+			clear(w.visited)
+		}
+		if w.emitted != nil {
+			clear(w.emitted)
+		}
+		if !w.visit(root, start, 0, 0) {
+			return
+		}
+	}
+}
+
+// 从状态 (node, pos) 继续遍历，返回 false 表示停止扫描
+func (w *dfaWalker) visit(node *dfaNode, pos, gap, widest int) bool {
+	if pos >= len(w.runes) {
+		return true
+	}
+	if w.gap != nil {
+		state := dfaWalkState{node: node, pos: pos, gap: gap, widest: widest}
+		if w.visited[state] {
+			return true
+		}
+		w.visited[state] = true
+	}
+
+	r := w.runes[pos]
+	if next, ok := node.children[r]; ok && !w.step(next, r, pos, 1, widest, stepChar) {
+		return false
+	}
+	if !w.substitute(node, r, pos, widest) {
+		return false
+	}
+	if w.pinyin != PinyinOff && isPinyinLetter(r) && !w.spell(node, pos, widest) {
+		return false
+	}
+	if w.homophones != 0 && !w.homophone(node, r, pos, widest) {
+		return false
+	}
+	if w.split && pos+1 < len(w.runes) && !w.compose(node, pos, widest) {
+		return false
+	}
+
+	// 只跳过候选词内部的字符，命中不会以噪声或间隔开头
+	if len(w.path) == 0 {
+		return true
+	}
+	if w.skip != nil && w.skip(r) {
+		return w.visit(node, pos+1, gap, widest)
+	}
+	if w.gap != nil && gap < w.gap.max {
+		return w.visit(node, pos+1, gap+1, max(widest, gap+1))
+	}
+
+	return true
+}
+
+// 以词中字符 c 匹配输入中从 pos 开始的 n 个字符，转移到子节点 next 后继续遍历
+// 以拼音匹配时 n 为拼音片段的长度，拆字时 n 为 2，其他方式 n 均为 1。
+func (w *dfaWalker) step(next *dfaNode, c rune, pos, n, widest int, kind stepKind) bool {
+	sub, spelled, replaced, initials, letters := 0, 0, 0, 0, 0
+	switch kind {
+	case stepChar:
+		if c != w.runes[pos] {
+			sub = 1
+		}
+	case stepHomophone:
+		sub, spelled, replaced = 1, 1, 1
+	case stepSplit:
+		sub = 1
+	case stepPinyinInitial:
+		sub, spelled, initials, letters = 1, 1, 1, n
+	default:
+		sub, spelled = 1, 1
+	}
+	pinyinEnd, lastKind := w.pinyinEnd, w.lastKind
+	w.pinyinEnd, w.lastKind = -1, kind
+	if kind == stepPinyinFull || kind == stepPinyinInitial {
+		w.pinyinEnd = pos + n
+	}
+
+	w.path = append(w.path, c)
+	w.subs += sub
+	w.spelled += spelled
+	w.replaced += replaced
+	w.initials += initials
+	w.letters += letters
+
+	// 允许重复时，一个字符可以连同其后相同的输入字符一起匹配，词尾只在重复段结束（或达到上限）时回调
+	repeatable := w.repeat != 0 && n == 1 && kind != stepPinyinFull && kind != stepPinyinInitial
+	for end := pos + n; ; end++ {
+		more := repeatable && end < len(w.runes) && w.runes[end] == w.runes[pos] && (w.repeat < 0 || end-pos < w.repeat)
+		if next.isLeaf && !more && !w.emit(next, end, widest) {
+			return false
+		}
+		if !w.visit(next, end, 0, widest) {
+			return false
+		}
+		if !more {
+			break
+		}
+	}
+	w.path = w.path[:len(w.path)-1]
+	w.subs -= sub
+	w.spelled -= spelled
+	w.replaced -= replaced
+	w.initials -= initials
+	w.letters -= letters
+	w.pinyinEnd, w.lastKind = pinyinEnd, lastKind
+
+	return true
+}
+
+// 尝试以输入字符 r 的等价字符匹配子节点，同一个等价字符只尝试一次
+func (w *dfaWalker) substitute(node *dfaNode, r rune, pos, widest int) bool {
+	var tried []rune
+	for _, equivalence := range w.equivalences {
+	next:
+		for _, c := range equivalence(r) {
+			if c == r {
+				continue
+			}
+			for _, t := range tried {
+				if t == c {
+					continue next
+				}
+			}
+			tried = append(tried, c)
+
+			if child, ok := node.children[c]; ok && !w.step(child, c, pos, 1, widest, stepChar) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// 以拼音匹配从 pos 开始的字母：依次尝试长度 1~6 的前缀作为全拼或首字母
+// 拼音片段不能紧接在其他字母之后，紧接上一个拼音片段时须与其类型相同，避免匹配英文单词的一部分。
+func (w *dfaWalker) spell(node *dfaNode, pos, widest int) bool {
+	mode := w.pinyin
+	if pos > 0 && isPinyinLetter(w.runes[pos-1]) {
+		if w.pinyinEnd != pos {
+			return true
+		}
+		if w.lastKind == stepPinyinFull {
+			mode &= PinyinFull
+		} else {
+			mode &= PinyinInitial
+		}
+	}
+
+	index := node.pinyinIndex()
+	run, size := pinyinRun(w.runes, pos)
+	for n := 1; n <= size; n++ {
+		var full []rune
+		if mode&PinyinFull != 0 {
+			full = index.full[string(run[:n])]
+			for _, c := range full {
+				if !w.step(node.children[c], c, pos, n, widest, stepPinyinFull) {
+					return false
+				}
+			}
+		}
+		if mode&PinyinInitial != 0 {
+		initial:
+			for _, c := range index.initial[string(run[:n])] {
+				for _, f := range full {
+					if f == c {
+						continue initial
+					}
+				}
+				child := node.children[c]
+				if !w.continues(child, pos+n) {
+					continue
+				}
+				if !w.step(child, c, pos, n, widest, stepPinyinInitial) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// 以读音相同（不计声调）的字匹配输入字符 r，多音字的每个读音都会尝试，同一个字只尝试一次
+func (w *dfaWalker) homophone(node *dfaNode, r rune, pos, widest int) bool {
+	if w.homophones > 0 && w.replaced >= w.homophones {
+		return true
+	}
+	syllables := Pinyin(r)
+	if len(syllables) == 0 {
+		return true
+	}
+
+	// 等价字符已在 substitute 中尝试过
+	var tried []rune
+	for _, equivalence := range w.equivalences {
+		tried = append(tried, equivalence(r)...)
+	}
+
+	index := node.pinyinIndex()
+	for _, syllable := range syllables {
+	next:
+		for _, c := range index.full[syllable] {
+			if c == r {
+				continue
+			}
+			for _, t := range tried {
+				if t == c {
+					continue next
+				}
+			}
+			tried = append(tried, c)
+
+			if !w.step(node.children[c], c, pos, 1, widest, stepHomophone) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// 以 pos 处相邻的两个部件合成的字匹配子节点
+func (w *dfaWalker) compose(node *dfaNode, pos, widest int) bool {
+	for _, c := range composeSplit(w.runes[pos], w.runes[pos+1]) {
+		if child, ok := node.children[c]; ok && !w.step(child, c, pos, 2, widest, stepSplit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// 判断首字母片段之后能否从 node 继续匹配 pos 处的字母，用于提前排除大量不可能的首字母组合
+// 紧跟的字母只能按原字符、等价字符或同为首字母的拼音继续匹配，允许间隔或该字母可跳过时不做判断。
+func (w *dfaWalker) continues(node *dfaNode, pos int) bool {
+	if pos >= len(w.runes) || w.gap != nil {
+		return true
+	}
+	r := w.runes[pos]
+	if !isPinyinLetter(r) || (w.skip != nil && w.skip(r)) {
+		return true
+	}
+
+	if _, ok := node.children[r]; ok {
+		return true
+	}
+	for _, equivalence := range w.equivalences {
+		for _, c := range equivalence(r) {
+			if _, ok := node.children[c]; ok {
+				return true
+			}
+		}
+	}
+	initial := [1]byte{byte(r) | 0x20}
+	return len(node.pinyinIndex().initial[string(initial[:])]) > 0
+}
+
+// 回调以 end 结束的命中，间隔超出该词的限制时忽略
+// 以拼音结尾的命中后面不能紧跟其他字母，单个汉字不单独以拼音或同音字命中。
+// 两个字的词都以同音字代替时原文不能全部是常用字，避免“是个”“故事”这类正常用语命中“石戈”“股市”。
+func (w *dfaWalker) emit(leaf *dfaNode, end, widest int) bool {
+	if w.pinyinEnd == end && end < len(w.runes) && isPinyinLetter(w.runes[end]) {
+		return true
+	}
+	if w.spelled == len(w.path) && len(w.path) < 2 {
+		return true
+	}
+	// 全部以首字母匹配时至少需要 3 个字母，避免“we”“my”“gg”这类英文短词命中
+	if w.initials == len(w.path) && w.letters < minInitialLetters {
+		return true
+	}
+
+	if len(w.path) == 2 && w.replaced == 2 && isCommonText(w.runes[w.start:end]) {
+		return true
+	}
+
+	h := hit{start: w.start, end: end}
+	if end-w.start != len(w.path) || w.subs > 0 {
+		h.word = string(w.path)
+	}
+
+	if w.gap != nil && widest > 0 {
+		limit := w.gap.global
+		if len(w.gap.words) > 0 {
+			limit = w.gap.limit(string(w.path))
+		}
+		if widest > limit {
+			return true
+		}
+	}
+	if w.emitted != nil {
+		key := dfaWalkHit{end: end, node: leaf}
+		if _, ok := w.emitted[key]; ok {
+			return true
+		}
+		w.emitted[key] = struct{}{}
+	}
+
+	return w.fn(h)
+}
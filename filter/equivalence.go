@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Equivalence 字符等价关系：返回匹配时可以代替输入字符 r 与词中字符比较的其他字符
+// 例如繁简等价下输入中的“發”可以代替“发”，因此能与词中的“发”匹配，词库无需同时收录繁简两种写法。
+type Equivalence func(r rune) []rune
+
+//go:embed data/ts_characters.txt
+var tsCharacters string
+
+var (
+	tsOnce  sync.Once
+	tsTable map[rune][]rune
+)
+
+// TraditionalSimplified 繁简等价：繁体字与其对应的简体字相互等价，一对多的情况（如“发”与“發”“髮”）同样支持
+func TraditionalSimplified(r rune) []rune {
+	tsOnce.Do(func() {
+		tsTable = make(map[rune][]rune)
+		scanner := bufio.NewScanner(strings.NewReader(tsCharacters))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			traditional, size := utf8.DecodeRuneInString(line)
+			simplified, _ := utf8.DecodeRuneInString(line[size+1:])
+			tsTable[traditional] = append(tsTable[traditional], simplified)
+			tsTable[simplified] = append(tsTable[simplified], traditional)
+		}
+	})
+
+	return tsTable[r]
+}
+
+//go:embed data/similar_glyphs.txt
+var similarGlyphs string
+
+var (
+	glyphOnce  sync.Once
+	glyphTable map[rune][]rune
+)
+
+// SimilarGlyph 形近字等价：外形相近、常被互相代替的字（如“己”“已”“巳”，“末”“未”）相互等价
+func SimilarGlyph(r rune) []rune {
+	glyphOnce.Do(func() {
+		glyphTable = make(map[rune][]rune)
+		scanner := bufio.NewScanner(strings.NewReader(similarGlyphs))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			group := []rune(line)
+			for _, c := range group {
+				for _, other := range group {
+					if other != c {
+						glyphTable[c] = append(glyphTable[c], other)
+					}
+				}
+			}
+		}
+	})
+
+	return glyphTable[r]
+}
+
+// Substitution 替换类：输入字符 -> 匹配时可以代替的词中字符，通过 Equivalence 方法用于匹配
+// 例如 {'1': {'i', 'l'}} 使“sh1t”能命中“shit”。与预先替换文本不同，原字符仍会参与匹配，不影响词库中本身含有数字的词。
+type Substitution map[rune][]rune
+
+// Equivalence 返回按替换类匹配的字符等价关系，替换类在调用时复制，之后的修改不会生效
+func (s Substitution) Equivalence() Equivalence {
+	table := make(map[rune][]rune, len(s))
+	for r, chars := range s {
+		table[r] = append([]rune(nil), chars...)
+	}
+
+	return func(r rune) []rune {
+		return table[r]
+	}
+}
+
+// LeetspeakTable 返回内置 leetspeak 替换类的副本（1→i/l、0→o、@→a/u、$→s 等，同时包含大小写），可修改后通过 Equivalence 使用
+func LeetspeakTable() Substitution {
+	return Substitution{
+		'0': {'o', 'O'},
+		'1': {'i', 'I', 'l', 'L'},
+		'2': {'z', 'Z'},
+		'3': {'e', 'E'},
+		'4': {'a', 'A'},
+		'5': {'s', 'S'},
+		'6': {'g', 'G', 'b', 'B'},
+		'7': {'t', 'T'},
+		'8': {'b', 'B'},
+		'9': {'g', 'G'},
+		'@': {'a', 'A', 'u', 'U'},
+		'$': {'s', 'S'},
+		'!': {'i', 'I'},
+		'¡': {'i', 'I'},
+		'|': {'l', 'L', 'i', 'I'},
+		'+': {'t', 'T'},
+		'€': {'e', 'E'},
+		'(': {'c', 'C'},
+		'<': {'c', 'C'},
+	}
+}
+
+// Leetspeak 内置 leetspeak 替换类构成的字符等价关系，使“sh1t”“a$$”“f@ck”“5b”等写法能命中英文词
+// 替换类只处理数字与符号，字母本身不做大小写折叠，“5B”“SH1T”需要同时使用 normalize.NFKCFold 才能命中“sb”“shit”。
+var Leetspeak = LeetspeakTable().Equivalence()
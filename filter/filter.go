@@ -1,9 +1,12 @@
 package filter
 
 type (
+	// Filter 敏感词匹配算法接口，实现须支持在增删词的同时并发查询
 	Filter interface {
 		// FindAll 找到所有敏感词
 		FindAll(text string) []string
+		// FindAllMatches 找到所有敏感词命中及其位置（按文本顺序）
+		FindAllMatches(text string) []Match
 		// FindAllCount 找到所有敏感词及出现次数
 		FindAllCount(text string) map[string]int
 		// FindOne 找到一个敏感词
@@ -12,6 +15,8 @@ type (
 		IsSensitive(text string) bool
 		// Replace 和谐敏感词
 		Replace(text string, repl rune) string
+		// ReplaceFunc 使用回调函数的返回值替换敏感词，可按命中的词、位置等生成替换内容
+		ReplaceFunc(text string, fn func(Match) string) string
 		// Remove 过滤铭感词
 		Remove(text string) string
 	}
@@ -19,3 +24,13 @@ type (
 
 // 接口实现验证
 var _ Filter = (*DfaModel)(nil)
+var _ Filter = (*AcModel)(nil)
+var _ Filter = (*DatModel)(nil)
+var _ Filter = (*NormalizedModel)(nil)
+var _ Filter = (*DateModel)(nil)
+
+var _ Model = (*DfaModel)(nil)
+var _ Model = (*AcModel)(nil)
+var _ Model = (*DatModel)(nil)
+var _ Model = (*NormalizedModel)(nil)
+var _ Model = (*DateModel)(nil)
@@ -0,0 +1,301 @@
+package filter
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Match 描述一次敏感词命中，所有区间均为左闭右开
+type Match struct {
+	Word       string // 命中的敏感词（词库中的原词）
+	Text       string // 命中的原文片段，跳过噪声或规范化后匹配时可能与 Word 不同
+	Start      int    // rune 起始下标
+	End        int    // rune 结束下标
+	ByteStart  int    // 字节起始下标（Go 字符串切片）
+	ByteEnd    int    // 字节结束下标
+	UTF16Start int    // UTF-16 起始下标（JS、Java 等客户端使用）
+	UTF16End   int    // UTF-16 结束下标
+
+	Categories []string // 命中词所属的分类（由 Manager 根据词库填充）
+	Weight     int      // 命中词的权重（由 Manager 根据词库填充，敏感日期命中为规则的权重）
+}
+
+// 算法扫描得到的原始命中区间（rune 下标，左闭右开）
+type hit struct {
+	start  int
+	end    int
+	word   string // 命中词与原文片段不同时（如跳过了噪声字符）记录词库中的原词
+	weight int    // 敏感日期命中记录规则的权重，词库命中为 0
+}
+
+// 将原始命中区间按文本顺序排序，剔除白名单内的命中并按重叠策略筛选后转换为 Match
+func (c *matchConfig) newMatches(text string, runes []rune, hits []hit) []Match {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].start != hits[j].start {
+			return hits[i].start < hits[j].start
+		}
+		return hits[i].end < hits[j].end
+	})
+	if cover := c.allowCover(text, len(runes)); cover != nil {
+		kept := hits[:0]
+		for _, h := range hits {
+			if cover[h.start] < h.end {
+				kept = append(kept, h)
+			}
+		}
+		hits = kept
+	}
+	hits = selectHits(hits, c.MatchMode())
+	if len(hits) == 0 {
+		return nil
+	}
+
+	byteOffsets, utf16Offsets := offsets(text, len(runes))
+	matches := make([]Match, 0, len(hits))
+	for _, h := range hits {
+		match := Match{
+			Word:       h.word,
+			Text:       text[byteOffsets[h.start]:byteOffsets[h.end]],
+			Start:      h.start,
+			End:        h.end,
+			ByteStart:  byteOffsets[h.start],
+			ByteEnd:    byteOffsets[h.end],
+			UTF16Start: utf16Offsets[h.start],
+			UTF16End:   utf16Offsets[h.end],
+			Weight:     h.weight,
+		}
+		if match.Word == "" {
+			match.Word = match.Text
+		}
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// 计算每个 rune 下标对应的字节与 UTF-16 下标，n 为文本的 rune 数
+// 非法 UTF-8 字节与 []rune 转换一样按单个字符计。
+func offsets(text string, n int) (byteOffsets, utf16Offsets []int) {
+	byteOffsets = make([]int, n+1)
+	utf16Offsets = make([]int, n+1)
+	i := 0
+	for b, r := range text {
+		byteOffsets[i] = b
+		utf16Offsets[i+1] = utf16Offsets[i] + 1
+		if r >= 0x10000 {
+			utf16Offsets[i+1]++
+		}
+		i++
+	}
+	byteOffsets[n] = len(text)
+
+	return byteOffsets, utf16Offsets
+}
+
+// 计算白名单覆盖范围：cover[i] 为起始位置不晚于 i 的白名单短语的最远结束位置
+// 命中 [start, end) 满足 cover[start] >= end 时完全落在某个白名单短语内；文本中没有白名单短语时返回 nil
+func (c *matchConfig) allowCover(text string, length int) []int {
+	allow := c.AllowList()
+	if allow == nil {
+		return nil
+	}
+	allowed := allow.FindAllMatches(text)
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	cover := make([]int, length+1)
+	for _, match := range allowed {
+		if match.End > cover[match.Start] {
+			cover[match.Start] = match.End
+		}
+	}
+	for i := 1; i <= length; i++ {
+		if cover[i-1] > cover[i] {
+			cover[i] = cover[i-1]
+		}
+	}
+
+	return cover
+}
+
+// 判断文本中是否存在白名单之外的命中，找到第一个即停止扫描
+func (c *matchConfig) isSensitive(text string, scan func(runes []rune, fn func(h hit) bool)) bool {
+	found := false
+	checked := false
+	var cover []int
+	runes := []rune(text)
+
+	scan(runes, func(h hit) bool {
+		// 仅在出现命中时才计算白名单覆盖范围
+		if !checked {
+			cover = c.allowCover(text, len(runes))
+			checked = true
+		}
+		if cover != nil && cover[h.start] >= h.end {
+			return true
+		}
+
+		found = true
+		return false
+	})
+
+	return found
+}
+
+// 按重叠策略筛选已排序的命中区间
+func selectHits(hits []hit, mode MatchMode) []hit {
+	if mode == MatchAllOverlapping {
+		return hits
+	}
+
+	selected := hits[:0]
+	next := 0 // 下一个命中允许的最小起始位置
+	for i := 0; i < len(hits); {
+		// 同一起始位置的命中按结束位置升序排列
+		j := i
+		for j+1 < len(hits) && hits[j+1].start == hits[i].start {
+			j++
+		}
+
+		if hits[i].start >= next {
+			chosen := hits[i]
+			if mode == MatchLeftmostLongest {
+				chosen = hits[j]
+			}
+			selected = append(selected, chosen)
+			next = chosen.end
+		}
+		i = j + 1
+	}
+
+	return selected
+}
+
+// 按出现顺序返回去重后的敏感词
+func findAll(matches []Match) []string {
+	var res []string
+	set := make(map[string]struct{})
+
+	for _, match := range matches {
+		if _, ok := set[match.Word]; !ok {
+			set[match.Word] = struct{}{}
+			res = append(res, match.Word)
+		}
+	}
+
+	return res
+}
+
+// 统计每个敏感词的命中次数
+func findAllCount(matches []Match) map[string]int {
+	res := make(map[string]int)
+
+	for _, match := range matches {
+		res[match.Word]++
+	}
+
+	return res
+}
+
+// 返回第一个命中的敏感词
+func findOne(matches []Match) string {
+	if len(matches) == 0 {
+		return ""
+	}
+
+	return matches[0].Word
+}
+
+// 将相互重叠的命中合并为一段（仅 MatchAllOverlapping 策略下出现）
+// 合并后的 Word 为起始位置最靠前的最长命中的词，区间与 Text 扩展为整段的并集，
+// Categories 为各命中分类的并集，Weight 取各命中权重的最大值。
+func mergeMatches(text string, matches []Match) []Match {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	merged := make([]Match, 0, len(matches))
+	for i := 0; i < len(matches); {
+		j := i + 1
+		end := matches[i].End
+		for j < len(matches) && matches[j].Start < end {
+			end = max(end, matches[j].End)
+			j++
+		}
+		merged = append(merged, mergeGroup(text, matches[i:j]))
+		i = j
+	}
+
+	return merged
+}
+
+// 合并一组相互重叠的命中
+func mergeGroup(text string, group []Match) Match {
+	cur, end := group[0], group[0]
+	var categories []string
+	weight := 0
+	for _, match := range group {
+		if match.Start == cur.Start && match.End > cur.End {
+			cur = match
+		}
+		if match.End > end.End {
+			end = match
+		}
+		for _, category := range match.Categories {
+			if !slices.Contains(categories, category) {
+				categories = append(categories, category)
+			}
+		}
+		weight = max(weight, match.Weight)
+	}
+	if len(group) == 1 {
+		return cur
+	}
+
+	cur.End, cur.ByteEnd, cur.UTF16End = end.End, end.ByteEnd, end.UTF16End
+	cur.Text = text[cur.ByteStart:cur.ByteEnd]
+	cur.Categories = categories
+	cur.Weight = weight
+	return cur
+}
+
+// ReplaceMatchesFunc 依次用 fn 的返回值替换文本中的命中片段，matches 须按文本顺序排列（如 FindAllMatches 的结果）
+// 相互重叠的命中先合并为一段，fn 收到的 Text 为整段原文，Categories 为各命中分类的并集。
+func ReplaceMatchesFunc(text string, matches []Match, fn func(Match) string) string {
+	if len(matches) == 0 {
+		return text
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(text))
+
+	last := 0
+	for _, match := range mergeMatches(text, matches) {
+		builder.WriteString(text[last:match.ByteStart])
+		builder.WriteString(fn(match))
+		last = match.ByteEnd
+	}
+	builder.WriteString(text[last:])
+
+	return builder.String()
+}
+
+// 将命中区间内的每个字符替换为 repl
+func replaceMatches(text string, matches []Match, repl rune) string {
+	return ReplaceMatchesFunc(text, matches, func(match Match) string {
+		return strings.Repeat(string(repl), match.End-match.Start)
+	})
+}
+
+// 移除所有命中区间内的字符
+func removeMatches(text string, matches []Match) string {
+	return ReplaceMatchesFunc(text, matches, func(match Match) string {
+		return ""
+	})
+}
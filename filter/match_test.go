@@ -0,0 +1,213 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindAllMatches(t *testing.T) {
+	words := []string{"武汉", "武汉海鲜市场", "bad", "😀笑"}
+	text := "a😀笑武汉海鲜市场bad"
+	want := []Match{
+		{Word: "😀笑", Text: "😀笑", Start: 1, End: 3, ByteStart: 1, ByteEnd: 8, UTF16Start: 1, UTF16End: 4},
+		{Word: "武汉", Text: "武汉", Start: 3, End: 5, ByteStart: 8, ByteEnd: 14, UTF16Start: 4, UTF16End: 6},
+		{Word: "武汉海鲜市场", Text: "武汉海鲜市场", Start: 3, End: 9, ByteStart: 8, ByteEnd: 26, UTF16Start: 4, UTF16End: 10},
+		{Word: "bad", Text: "bad", Start: 9, End: 12, ByteStart: 26, ByteEnd: 29, UTF16Start: 10, UTF16End: 13},
+	}
+
+	models := map[string]interface {
+		Model
+		AddWords(...string)
+	}{
+		"dfa": NewDfaModel(),
+		"ac":  NewAcModel(),
+		"dat": NewDatModel(),
+	}
+	for name, model := range models {
+		model.AddWords(words...)
+		model.Flush()
+
+		got := model.FindAllMatches(text)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: FindAllMatches = %+v, want %+v", name, got, want)
+		}
+		for _, match := range got {
+			if text[match.ByteStart:match.ByteEnd] != match.Word {
+				t.Errorf("%s: byte offsets of %q point to %q", name, match.Word, text[match.ByteStart:match.ByteEnd])
+			}
+		}
+
+		if got := model.FindOne(text); got != "😀笑" {
+			t.Errorf("%s: FindOne = %q", name, got)
+		}
+		if got := model.Replace(text, '*'); got != "a***********" {
+			t.Errorf("%s: Replace = %q", name, got)
+		}
+		if got := model.Remove(text); got != "a" {
+			t.Errorf("%s: Remove = %q", name, got)
+		}
+	}
+}
+
+func TestMatchMode(t *testing.T) {
+	words := []string{"武汉", "武汉海鲜市场", "海鲜", "鲜市"}
+	text := "去武汉海鲜市场玩，武汉"
+
+	tests := []struct {
+		mode    MatchMode
+		findAll []string
+		count   map[string]int
+		findOne string
+		replace string
+		remove  string
+	}{
+		{
+			mode:    MatchAllOverlapping,
+			findAll: []string{"武汉", "武汉海鲜市场", "海鲜", "鲜市"},
+			count:   map[string]int{"武汉": 2, "武汉海鲜市场": 1, "海鲜": 1, "鲜市": 1},
+			findOne: "武汉",
+			replace: "去******玩，**",
+			remove:  "去玩，",
+		},
+		{
+			mode:    MatchLeftmostLongest,
+			findAll: []string{"武汉海鲜市场", "武汉"},
+			count:   map[string]int{"武汉海鲜市场": 1, "武汉": 1},
+			findOne: "武汉海鲜市场",
+			replace: "去******玩，**",
+			remove:  "去玩，",
+		},
+		{
+			mode:    MatchLeftmostShortest,
+			findAll: []string{"武汉", "海鲜"},
+			count:   map[string]int{"武汉": 2, "海鲜": 1},
+			findOne: "武汉",
+			replace: "去****市场玩，**",
+			remove:  "去市场玩，",
+		},
+	}
+
+	for _, tt := range tests {
+		models := map[string]interface {
+			Model
+			AddWords(...string)
+			SetMatchMode(MatchMode)
+		}{
+			"dfa": NewDfaModel(),
+			"ac":  NewAcModel(),
+			"dat": NewDatModel(),
+		}
+
+		for name, model := range models {
+			model.AddWords(words...)
+			model.Flush()
+			model.SetMatchMode(tt.mode)
+
+			if got := model.FindAll(text); !reflect.DeepEqual(got, tt.findAll) {
+				t.Errorf("%s mode %d: FindAll = %v, want %v", name, tt.mode, got, tt.findAll)
+			}
+			if got := model.FindAllCount(text); !reflect.DeepEqual(got, tt.count) {
+				t.Errorf("%s mode %d: FindAllCount = %v, want %v", name, tt.mode, got, tt.count)
+			}
+			if got := model.FindOne(text); got != tt.findOne {
+				t.Errorf("%s mode %d: FindOne = %q, want %q", name, tt.mode, got, tt.findOne)
+			}
+			if got := model.Replace(text, '*'); got != tt.replace {
+				t.Errorf("%s mode %d: Replace = %q, want %q", name, tt.mode, got, tt.replace)
+			}
+			if got := model.Remove(text); got != tt.remove {
+				t.Errorf("%s mode %d: Remove = %q, want %q", name, tt.mode, got, tt.remove)
+			}
+			if !model.IsSensitive(text) {
+				t.Errorf("%s mode %d: IsSensitive = false", name, tt.mode)
+			}
+		}
+	}
+}
+
+func TestReplaceFunc(t *testing.T) {
+	model := NewDfaModel()
+	model.AddWords("武汉", "武汉海鲜市场", "鲜市场玩", "毒品")
+	text := "去武汉海鲜市场玩耍，不碰毒品"
+
+	tests := []struct {
+		name string
+		mode MatchMode
+		fn   func(Match) string
+		want string
+	}{
+		{
+			name: "fixed",
+			mode: MatchLeftmostLongest,
+			fn:   func(Match) string { return "[已屏蔽]" },
+			want: "去[已屏蔽]玩耍，不碰[已屏蔽]",
+		},
+		{
+			name: "keep first rune",
+			mode: MatchLeftmostLongest,
+			fn: func(match Match) string {
+				runes := []rune(match.Word)
+				return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+			},
+			want: "去武*****玩耍，不碰毒*",
+		},
+		{
+			name: "overlapping merged",
+			mode: MatchAllOverlapping,
+			fn: func(match Match) string {
+				return "<" + match.Word + "|" + match.Text + ">"
+			},
+			want: "去<武汉海鲜市场|武汉海鲜市场玩>耍，不碰<毒品|毒品>",
+		},
+	}
+
+	for _, tt := range tests {
+		model.SetMatchMode(tt.mode)
+		if got := model.ReplaceFunc(text, tt.fn); got != tt.want {
+			t.Errorf("%s: ReplaceFunc = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// 部分重叠的命中合并后 Text 覆盖整段
+	model = NewDfaModel()
+	model.AddWords("武汉", "汉海鲜")
+	got := model.ReplaceFunc("去武汉海鲜吃", func(match Match) string { return "[" + match.Text + "]" })
+	if want := "去[武汉海鲜]吃"; got != want {
+		t.Errorf("ReplaceFunc = %q, want %q", got, want)
+	}
+}
+
+func TestAllowList(t *testing.T) {
+	allow := NewAcModel()
+	allow.AddWords("南京市长江大桥")
+	allow.Flush()
+
+	for _, mode := range []MatchMode{MatchAllOverlapping, MatchLeftmostLongest, MatchLeftmostShortest} {
+		model := NewDfaModel()
+		model.AddWords("市长", "江大", "长江大桥下")
+		model.SetMatchMode(mode)
+		model.SetAllowList(allow)
+
+		if model.IsSensitive("南京市长江大桥") {
+			t.Errorf("mode %d: IsSensitive inside allowed phrase = true", mode)
+		}
+		if got := model.FindAll("南京市长江大桥下，市长来了"); !reflect.DeepEqual(got, []string{"长江大桥下", "市长"}) {
+			t.Errorf("mode %d: FindAll = %v", mode, got)
+		}
+		if got := model.Replace("南京市长江大桥，市长", '*'); got != "南京市长江大桥，**" {
+			t.Errorf("mode %d: Replace = %q", mode, got)
+		}
+	}
+
+	// 白名单支持动态删除
+	model := NewAcModel()
+	model.AddWords("市长")
+	model.Flush()
+	model.SetAllowList(allow)
+	allow.DelWord("南京市长江大桥")
+	allow.Flush()
+	if !model.IsSensitive("南京市长江大桥") {
+		t.Error("IsSensitive after allow-list removal = false")
+	}
+}
@@ -0,0 +1,229 @@
+package filter
+
+import (
+	"github.com/zmexing/go-sensitive-word/normalize"
+	"slices"
+	"sync"
+)
+
+// Model 可动态增删词的敏感词匹配器，DfaModel、AcModel、DatModel 均实现了该接口
+type Model interface {
+	Filter
+	AddWord(word string)
+	DelWord(word string)
+	Listen(addChan, delChan <-chan string)
+	Flush() // 等待此前的增删词对查询生效
+}
+
+// NormalizedModel 在匹配器之前执行规范化
+// 词库中的词在加入匹配器前先规范化，待查文本规范化后再匹配，命中位置映射回原文，
+// 因此 Replace、Remove 等方法直接作用于原文。命中的 Match.Word 为词库中的原词，Match.Text 为原文片段。
+type NormalizedModel struct {
+	model      Model
+	normalizer normalize.Normalizer
+
+	mu    sync.RWMutex
+	words map[string][]string // 规范化后的词 -> 词库中的原词（可能有多个原词规范化为同一个词）
+}
+
+// NewNormalizedModel 创建在 model 之前执行 normalizer 的匹配器
+// 已加入 model 的词不会被规范化，词应通过 NormalizedModel 的 AddWord 或 Listen 加入。
+func NewNormalizedModel(model Model, normalizer normalize.Normalizer) *NormalizedModel {
+	return &NormalizedModel{
+		model:      model,
+		normalizer: normalizer,
+		words:      make(map[string][]string),
+	}
+}
+
+// Unwrap 返回被包装的匹配器
+func (m *NormalizedModel) Unwrap() Model {
+	return m.model
+}
+
+// Normalize 返回文本规范化后的结果
+func (m *NormalizedModel) Normalize(text string) string {
+	return normalize.String(m.normalizer, text)
+}
+
+// 添加多个词
+func (m *NormalizedModel) AddWords(words ...string) {
+	for _, word := range words {
+		m.AddWord(word)
+	}
+}
+
+// 规范化后添加单个词
+func (m *NormalizedModel) AddWord(word string) {
+	if word == "" {
+		return
+	}
+	normalized := m.Normalize(word)
+	if normalized == "" {
+		return
+	}
+
+	m.mu.Lock()
+	exist := false
+	for _, w := range m.words[normalized] {
+		if w == word {
+			exist = true
+			break
+		}
+	}
+	if !exist {
+		m.words[normalized] = append(m.words[normalized], word)
+	}
+	m.mu.Unlock()
+
+	m.model.AddWord(normalized)
+}
+
+// 删除多个词
+func (m *NormalizedModel) DelWords(words ...string) {
+	for _, word := range words {
+		m.DelWord(word)
+	}
+}
+
+// 删除单个词，其他原词规范化后与之相同时匹配器中的词仍会保留
+func (m *NormalizedModel) DelWord(word string) {
+	if word == "" {
+		return
+	}
+	normalized := m.Normalize(word)
+
+	m.mu.Lock()
+	words := m.words[normalized]
+	kept := make([]string, 0, len(words))
+	for _, w := range words {
+		if w != word {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) == 0 {
+		delete(m.words, normalized)
+	} else {
+		m.words[normalized] = kept
+	}
+	m.mu.Unlock()
+
+	if len(kept) == 0 {
+		m.model.DelWord(normalized)
+	}
+}
+
+// 监听新增和删除通道，收到同步信号（空字符串）时等待此前的变更生效
+func (m *NormalizedModel) Listen(addChan, delChan <-chan string) {
+	go func() {
+		for word := range addChan {
+			if word == "" {
+				m.Flush()
+				continue
+			}
+			m.AddWord(word)
+		}
+	}()
+
+	go func() {
+		for word := range delChan {
+			if word == "" {
+				m.Flush()
+				continue
+			}
+			m.DelWord(word)
+		}
+	}()
+}
+
+// Flush 等待此前的增删词对查询生效
+func (m *NormalizedModel) Flush() {
+	m.model.Flush()
+}
+
+// Originals 返回与 word 规范化后相同的所有词库原词（按加入顺序），不在词库中时只返回 word 本身
+// 命中的 Match.Word 为其中第一个，各原词的分类与权重需要合并时使用。
+func (m *NormalizedModel) Originals(word string) []string {
+	if words := m.originals(m.Normalize(word)); len(words) > 0 {
+		return slices.Clone(words)
+	}
+	return []string{word}
+}
+
+// 返回规范化后的词对应的所有词库原词
+func (m *NormalizedModel) originals(normalized string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.words[normalized]
+}
+
+// 返回规范化后的词对应的第一个词库原词，找不到时原样返回
+func (m *NormalizedModel) original(normalized string) string {
+	if words := m.originals(normalized); len(words) > 0 {
+		return words[0]
+	}
+	return normalized
+}
+
+// 查找文本中所有敏感词命中（按文本顺序），位置均为原文中的位置
+func (m *NormalizedModel) FindAllMatches(text string) []Match {
+	runes := []rune(text)
+	normalized := normalize.NewText(m.normalizer, runes)
+	matches := m.model.FindAllMatches(string(normalized.Runes))
+	if len(matches) == 0 {
+		return nil
+	}
+
+	byteOffsets, utf16Offsets := offsets(text, len(runes))
+	for i, match := range matches {
+		span := normalized.Span(match.Start, match.End)
+		matches[i] = Match{
+			Word:       m.original(match.Word),
+			Text:       text[byteOffsets[span.Start]:byteOffsets[span.End]],
+			Start:      span.Start,
+			End:        span.End,
+			ByteStart:  byteOffsets[span.Start],
+			ByteEnd:    byteOffsets[span.End],
+			UTF16Start: utf16Offsets[span.Start],
+			UTF16End:   utf16Offsets[span.End],
+		}
+	}
+
+	return matches
+}
+
+// 查找文本中所有敏感词
+func (m *NormalizedModel) FindAll(text string) []string {
+	return findAll(m.FindAllMatches(text))
+}
+
+// 查找所有敏感词及其出现次数
+func (m *NormalizedModel) FindAllCount(text string) map[string]int {
+	return findAllCount(m.FindAllMatches(text))
+}
+
+// 查找一个敏感词（返回文本中第一个命中）
+func (m *NormalizedModel) FindOne(text string) string {
+	return findOne(m.FindAllMatches(text))
+}
+
+// 判断文本中是否包含敏感词
+func (m *NormalizedModel) IsSensitive(text string) bool {
+	return m.model.IsSensitive(m.Normalize(text))
+}
+
+// 将敏感词替换为指定字符（如 *）
+func (m *NormalizedModel) Replace(text string, repl rune) string {
+	return replaceMatches(text, m.FindAllMatches(text), repl)
+}
+
+// 使用回调函数的返回值替换每个敏感词，重叠的命中会先合并为一段
+func (m *NormalizedModel) ReplaceFunc(text string, fn func(Match) string) string {
+	return ReplaceMatchesFunc(text, m.FindAllMatches(text), fn)
+}
+
+// 将敏感词从文本中完全移除
+func (m *NormalizedModel) Remove(text string) string {
+	return removeMatches(text, m.FindAllMatches(text))
+}
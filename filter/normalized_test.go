@@ -0,0 +1,66 @@
+package filter
+
+import (
+	"github.com/zmexing/go-sensitive-word/normalize"
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+func TestNormalizedModel(t *testing.T) {
+	lower := normalize.Map(unicode.ToLower)
+	strip := normalize.Map(func(r rune) rune {
+		if r == '\u200b' {
+			return -1
+		}
+		return r
+	})
+
+	models := map[string]Model{
+		"dfa": NewDfaModel(),
+		"ac":  NewAcModel(),
+		"dat": NewDatModel(),
+	}
+	for name, inner := range models {
+		model := NewNormalizedModel(inner, normalize.Pipeline{strip, lower})
+		model.AddWords("Bad", "BAD", "马斯克")
+		model.Flush()
+
+		text := "so bAd，马\u200b斯\u200b克"
+		want := []Match{
+			{Word: "Bad", Text: "bAd", Start: 3, End: 6, ByteStart: 3, ByteEnd: 6, UTF16Start: 3, UTF16End: 6},
+			{Word: "马斯克", Text: "马\u200b斯\u200b克", Start: 7, End: 12, ByteStart: 9, ByteEnd: 24, UTF16Start: 7, UTF16End: 12},
+		}
+		if got := model.FindAllMatches(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: FindAllMatches = %+v, want %+v", name, got, want)
+		}
+		if got, want := model.Replace(text, '*'), "so ***，*****"; got != want {
+			t.Errorf("%s: Replace = %q, want %q", name, got, want)
+		}
+		if got, want := model.Remove(text), "so ，"; got != want {
+			t.Errorf("%s: Remove = %q, want %q", name, got, want)
+		}
+		if !model.IsSensitive("马\u200b斯克") {
+			t.Errorf("%s: IsSensitive = false", name)
+		}
+
+		if got, want := model.Originals("bad"), []string{"Bad", "BAD"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Originals = %v, want %v", name, got, want)
+		}
+		if got, want := model.Originals("Good"), []string{"Good"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Originals = %v, want %v", name, got, want)
+		}
+
+		// 规范化后相同的词全部删除后才从匹配器中移除
+		model.DelWord("Bad")
+		model.Flush()
+		if got := model.FindAll("BAD"); !reflect.DeepEqual(got, []string{"BAD"}) {
+			t.Errorf("%s: FindAll after deleting one alias = %v", name, got)
+		}
+		model.DelWord("BAD")
+		model.Flush()
+		if model.IsSensitive("bad") {
+			t.Errorf("%s: IsSensitive after deleting all aliases = true", name)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package filter
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// PinyinMode 拼音匹配方式，可组合使用
+type PinyinMode uint32
+
+const (
+	PinyinFull    PinyinMode = 1 << iota // 全拼匹配，如“falungong”“法lun功”，设置 Skip 后也可为“fa lun gong”
+	PinyinInitial                        // 首字母匹配，如“flg”，zh、ch、sh 也可作为首字母，a、e、o 开头的零声母音节不参与；全部以首字母匹配时至少需要 3 个字母
+
+	PinyinOff PinyinMode = 0                          // 关闭拼音匹配（默认）
+	PinyinAll            = PinyinFull | PinyinInitial // 全拼与首字母混合匹配
+)
+
+//go:embed data/pinyin.txt
+var pinyinData string
+
+var (
+	pinyinOnce  sync.Once
+	pinyinTable map[rune][]string // 汉字 -> 不带声调的拼音（多音字有多个）
+)
+
+// 加载内置拼音表
+func loadPinyin() {
+	pinyinOnce.Do(func() {
+		pinyinTable = make(map[rune][]string)
+
+		scanner := bufio.NewScanner(strings.NewReader(pinyinData))
+		scanner.Buffer(nil, len(pinyinData))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			syllable, chars, _ := strings.Cut(line, "\t")
+			for _, r := range chars {
+				pinyinTable[r] = append(pinyinTable[r], syllable)
+			}
+		}
+	})
+}
+
+//go:embed data/common_characters.txt
+var commonCharactersData string
+
+var (
+	commonOnce       sync.Once
+	commonCharacters map[rune]bool // 最常用的汉字
+)
+
+// 判断文本是否全部由最常用的汉字组成，用于排除以同音字命中的正常用语
+func isCommonText(runes []rune) bool {
+	commonOnce.Do(func() {
+		commonCharacters = make(map[rune]bool)
+		scanner := bufio.NewScanner(strings.NewReader(commonCharactersData))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			for _, r := range line {
+				commonCharacters[r] = true
+			}
+		}
+	})
+
+	for _, r := range runes {
+		if !commonCharacters[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// Pinyin 返回汉字不带声调的拼音（ü 记作 v），多音字返回所有常用读音，非汉字返回 nil
+func Pinyin(r rune) []string {
+	loadPinyin()
+	return pinyinTable[r]
+}
+
+// 拼音索引：全拼或首字母 -> 子节点中读该音的汉字
+type pinyinIndex struct {
+	full    map[string][]rune
+	initial map[string][]rune
+}
+
+// 返回节点的拼音索引，首次使用时根据子节点构建
+// 已发布的节点不再修改，索引只需构建一次；写操作复制出的新节点会重新构建。
+func (n *dfaNode) pinyinIndex() *pinyinIndex {
+	if index := n.pinyin.Load(); index != nil {
+		return index
+	}
+
+	loadPinyin()
+	index := &pinyinIndex{
+		full:    make(map[string][]rune),
+		initial: make(map[string][]rune),
+	}
+	for r := range n.children {
+		for _, syllable := range pinyinTable[r] {
+			addPinyin(index.full, syllable, r)
+			// 零声母音节（以 a、e、o 开头）没有声母，不参与首字母匹配
+			if strings.IndexByte("aeo", syllable[0]) >= 0 {
+				continue
+			}
+			addPinyin(index.initial, syllable[:1], r)
+			if len(syllable) > 2 && syllable[1] == 'h' && strings.IndexByte("zcs", syllable[0]) >= 0 {
+				addPinyin(index.initial, syllable[:2], r)
+			}
+		}
+	}
+	n.pinyin.Store(index)
+
+	return index
+}
+
+// 添加一条索引，同一个键下的汉字不重复
+func addPinyin(index map[string][]rune, key string, r rune) {
+	for _, c := range index[key] {
+		if c == r {
+			return
+		}
+	}
+	index[key] = append(index[key], r)
+}
+
+// 判断是否为拼音中使用的字母
+func isPinyinLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// 读取从 pos 开始的字母（转为小写），最多取一个音节的最大长度
+func pinyinRun(runes []rune, pos int) (run [6]byte, n int) {
+	for ; n < len(run) && pos+n < len(runes) && isPinyinLetter(runes[pos+n]); n++ {
+		run[n] = byte(runes[pos+n]) | 0x20
+	}
+	return run, n
+}
@@ -0,0 +1,90 @@
+package filter
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+//go:embed data/split_characters.txt
+var splitCharacters string
+
+var (
+	splitOnce  sync.Once
+	splitTable map[[2]rune][]rune // 两个部件 -> 由其合成的字
+)
+
+// 偏旁的独立字形或繁体写法，拆字时可以代替对应的偏旁
+var radicalForms = map[rune]rune{
+	'人': '亻',
+	'水': '氵',
+	'手': '扌',
+	'言': '讠',
+	'訁': '讠',
+	'金': '钅',
+	'釒': '钅',
+	'糸': '纟',
+	'糹': '纟',
+	'犬': '犭',
+	'心': '忄',
+	'刀': '刂',
+	'示': '礻',
+	'衣': '衤',
+	'食': '饣',
+	'飠': '饣',
+	'竹': '⺮',
+}
+
+// 返回相邻的两个部件 a、b 可以合成的字，部件也可以是偏旁的独立字形
+func composeSplit(a, b rune) []rune {
+	splitOnce.Do(func() {
+		splitTable = make(map[[2]rune][]rune)
+		scanner := bufio.NewScanner(strings.NewReader(splitCharacters))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			char, parts, _ := strings.Cut(line, "\t")
+			c, p := []rune(char), []rune(parts)
+			if len(c) != 1 || len(p) != 2 {
+				continue
+			}
+			key := [2]rune{p[0], p[1]}
+			splitTable[key] = append(splitTable[key], c[0])
+		}
+	})
+
+	chars := splitTable[[2]rune{a, b}]
+	radicalA, okA := radicalForms[a]
+	radicalB, okB := radicalForms[b]
+	if okA {
+		chars = appendNew(chars, splitTable[[2]rune{radicalA, b}])
+	}
+	if okB {
+		chars = appendNew(chars, splitTable[[2]rune{a, radicalB}])
+	}
+	if okA && okB {
+		chars = appendNew(chars, splitTable[[2]rune{radicalA, radicalB}])
+	}
+
+	return chars
+}
+
+// 将 src 中 dst 尚未包含的字追加到 dst，dst 可能与拆字表共享，追加前先复制
+func appendNew(dst, src []rune) []rune {
+	for _, r := range src {
+		exist := false
+		for _, c := range dst {
+			if c == r {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			dst = append(dst[:len(dst):len(dst)], r)
+		}
+	}
+	return dst
+}
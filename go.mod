@@ -7,6 +7,7 @@ toolchain go1.22.1
 require (
 	github.com/imroc/req/v3 v3.43.3
 	github.com/orcaman/concurrent-map/v2 v2.0.1
+	golang.org/x/text v0.14.0
 )
 
 require (
@@ -27,6 +28,5 @@ require (
 	golang.org/x/mod v0.16.0 // indirect
 	golang.org/x/net v0.22.0 // indirect
 	golang.org/x/sys v0.18.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
 	golang.org/x/tools v0.19.0 // indirect
 )
@@ -2,21 +2,27 @@ package go_sensitive_word
 
 import (
 	"errors"
+	"fmt"
 	"github.com/zmexing/go-sensitive-word/filter"
+	"github.com/zmexing/go-sensitive-word/normalize"
 	"github.com/zmexing/go-sensitive-word/store"
+	"slices"
+	"sync/atomic"
 )
 
 // Manager 是敏感词过滤系统的核心结构，整合了词库存储和过滤算法
 type Manager struct {
 	store.Store   //  // 词库存储接口（支持内存、本地文件、远程等）
 	filter.Filter // // 敏感词匹配算法接口（如 DFA）
+
+	scoreOption atomic.Pointer[ScoreOption] // 风险评分配置
 }
 
 // NewFilter 初始化过滤器和词库存储
 // 参数：storeOption 指定存储方式，filterOption 指定过滤算法
 func NewFilter(storeOption StoreOption, filterOption FilterOption) (*Manager, error) {
 	var filterStore store.Store
-	var myFilter filter.Filter
+	var myFilter filter.Model
 
 	switch storeOption.Type {
 	case StoreMemory: // 使用内存词库
@@ -25,18 +31,256 @@ func NewFilter(storeOption StoreOption, filterOption FilterOption) (*Manager, er
 		return nil, errors.New("invalid store type")
 	}
 
+	if filterOption.MaxGap < 0 {
+		return nil, errors.New("invalid max gap")
+	}
+	if filterOption.Homophone < HomophoneAll {
+		return nil, errors.New("invalid homophone limit")
+	}
+	if filterOption.MaxRepeat < RepeatAll {
+		return nil, errors.New("invalid max repeat")
+	}
+	if err := validateDfaOnly(filterOption); err != nil {
+		return nil, err
+	}
+
+	// 白名单短语匹配器，与敏感词词库一样实时接收新增/删除通知
+	allowModel := filter.NewAcModel()
+	var allowList filter.Model = allowModel
+	// DFA 在匹配时跳过不可见字符与噪声字符，白名单短语匹配时删除这些字符，使“南京市\u200b长江大桥”“南京市 长江大桥”同样放行
+	if filterOption.Type == FilterDfa {
+		var ignored normalize.Pipeline
+		if filterOption.IgnoreInvisible {
+			ignored = append(ignored, normalize.StripInvisible)
+		}
+		if skip := filterOption.Skip; skip != nil {
+			ignored = append(ignored, normalize.Map(func(r rune) rune {
+				if skip(r) {
+					return -1
+				}
+				return r
+			}))
+		}
+		if len(ignored) > 0 {
+			allowList = filter.NewNormalizedModel(allowModel, ignored)
+		}
+	}
+
 	switch filterOption.Type {
 	case FilterDfa: // 使用 DFA 算法
 		dfaModel := filter.NewDfaModel()
-		// 启动监听协程，实时接收新增/删除词的通知
-		go dfaModel.Listen(filterStore.GetAddChan(), filterStore.GetDelChan())
+		dfaModel.SetMatchMode(filterOption.Mode)
+		dfaModel.SetAllowList(allowList)
+		dfaModel.SetSkip(filterOption.Skip)
+		dfaModel.SetMaxGap(filterOption.MaxGap)
+		dfaModel.SetIgnoreInvisible(filterOption.IgnoreInvisible)
+		dfaModel.SetEquivalences(filterOption.Equivalences...)
+		dfaModel.SetPinyin(filterOption.Pinyin)
+		dfaModel.SetHomophone(filterOption.Homophone)
+		dfaModel.SetSplitCharacters(filterOption.SplitCharacters)
+		dfaModel.SetMaxRepeat(filterOption.MaxRepeat)
 		myFilter = dfaModel
+	case FilterAC: // 使用 AC 自动机
+		acModel := filter.NewAcModel()
+		acModel.SetMatchMode(filterOption.Mode)
+		acModel.SetAllowList(allowList)
+		myFilter = acModel
+	case FilterDoubleArray: // 使用双数组 Trie
+		datModel := filter.NewDatModel()
+		datModel.SetMatchMode(filterOption.Mode)
+		datModel.SetAllowList(allowList)
+		myFilter = datModel
 	default:
 		return nil, errors.New("invalid filter type")
 	}
 
-	return &Manager{
+	// 配置了规范化流水线时，词库中的词与待查文本都先规范化再匹配，白名单短语同样规范化
+	// DFA 在匹配时直接跳过不可见字符，其他算法通过规范化删除不可见字符
+	pipeline := normalize.Pipeline(filterOption.Normalizers)
+	if filterOption.IgnoreInvisible && filterOption.Type != FilterDfa {
+		pipeline = append(normalize.Pipeline{normalize.StripInvisible}, pipeline...)
+	}
+	if len(pipeline) > 0 {
+		myFilter = filter.NewNormalizedModel(myFilter, pipeline)
+		allowList = filter.NewNormalizedModel(allowList, pipeline)
+	}
+
+	// 配置了敏感日期时，在词库命中之外识别日期写法，两者合并后统一按重叠策略与白名单筛选
+	if len(filterOption.Dates) > 0 {
+		// 未设置权重的规则按默认权重计，词库中没有同名词时命中也参与风险评分
+		dates := make([]DateRule, len(filterOption.Dates))
+		for i, rule := range filterOption.Dates {
+			if rule.Weight == 0 {
+				rule.Weight = store.DefaultWeight
+			}
+			dates[i] = rule
+		}
+		dateModel := filter.NewDateModel(myFilter)
+		if err := dateModel.SetDates(dates...); err != nil {
+			return nil, err
+		}
+		dateModel.SetMatchMode(filterOption.Mode)
+		dateModel.SetAllowList(allowList)
+		myFilter = dateModel
+	}
+
+	// 启动监听协程，实时接收新增/删除词的通知（AC 自动机与双数组在后台批量重建，增删词的方法返回前等待重建完成）
+	go myFilter.Listen(filterStore.GetAddChan(), filterStore.GetDelChan())
+	go allowList.Listen(filterStore.GetAllowAddChan(), filterStore.GetAllowDelChan())
+
+	manager := &Manager{
 		Store:  filterStore,
 		Filter: myFilter,
-	}, nil
+	}
+	manager.SetScoreOption(DefaultScoreOption)
+
+	return manager, nil
+}
+
+// 检查仅 FilterDfa 支持的模糊匹配选项，其他过滤算法设置了这些选项时返回错误
+func validateDfaOnly(filterOption FilterOption) error {
+	if filterOption.Type == FilterDfa {
+		return nil
+	}
+
+	options := []struct {
+		name string
+		set  bool
+	}{
+		{"Skip", filterOption.Skip != nil},
+		{"MaxGap", filterOption.MaxGap > 0},
+		{"Equivalences", len(filterOption.Equivalences) > 0},
+		{"Pinyin", filterOption.Pinyin != PinyinOff},
+		{"Homophone", filterOption.Homophone != 0},
+		{"SplitCharacters", filterOption.SplitCharacters},
+		{"MaxRepeat", filterOption.MaxRepeat != 0},
+	}
+	for _, option := range options {
+		if option.set {
+			return fmt.Errorf("%s is only supported by FilterDfa", option.name)
+		}
+	}
+
+	return nil
+}
+
+// FindAllMatches 查找所有命中，并附带命中词在词库中所属的分类与权重
+func (m *Manager) FindAllMatches(text string) []filter.Match {
+	matches := m.Filter.FindAllMatches(text)
+	for i := range matches {
+		m.fillMatch(&matches[i])
+	}
+
+	return matches
+}
+
+// ReplaceFunc 使用回调函数的返回值替换敏感词，回调收到的命中附带所属分类与权重
+// 相互重叠的命中合并为一段，分类为各命中分类的并集，权重取最大值。
+func (m *Manager) ReplaceFunc(text string, fn func(filter.Match) string) string {
+	return filter.ReplaceMatchesFunc(text, m.FindAllMatches(text), fn)
+}
+
+// SetWordMaxGap 为指定的词单独设置最大间隔（相邻字符之间最多允许插入的任意字符数），优先于 FilterOption.MaxGap
+// 仅 FilterDfa 支持。
+func (m *Manager) SetWordMaxGap(gap int, words ...string) error {
+	if gap < 0 {
+		return errors.New("invalid max gap")
+	}
+	model := m.Filter
+	if dated, ok := model.(*filter.DateModel); ok {
+		model = dated.Unwrap()
+	}
+	// 规范化后匹配时，间隔设置作用于规范化后的词
+	if normalized, ok := model.(*filter.NormalizedModel); ok {
+		normalizedWords := make([]string, 0, len(words))
+		for _, word := range words {
+			normalizedWords = append(normalizedWords, normalized.Normalize(word))
+		}
+		words = normalizedWords
+		model = normalized.Unwrap()
+	}
+	dfaModel, ok := model.(*filter.DfaModel)
+	if !ok {
+		return errors.New("max gap is only supported by FilterDfa")
+	}
+
+	dfaModel.SetWordMaxGap(gap, words...)
+	return nil
+}
+
+// Stats 返回双数组的节点数与内存占用，仅 FilterDoubleArray 支持
+func (m *Manager) Stats() (filter.DatStats, error) {
+	model := m.Filter
+	if dated, ok := model.(*filter.DateModel); ok {
+		model = dated.Unwrap()
+	}
+	if normalized, ok := model.(*filter.NormalizedModel); ok {
+		model = normalized.Unwrap()
+	}
+	datModel, ok := model.(*filter.DatModel)
+	if !ok {
+		return filter.DatStats{}, errors.New("stats are only supported by FilterDoubleArray")
+	}
+
+	return datModel.Stats(), nil
+}
+
+// 根据词库补充命中词的分类与权重
+// 规范化后匹配时，多个原词可能规范化为同一个词（如“FUCK”与“fuck”），分类取并集，权重取最大值。
+func (m *Manager) fillMatch(match *filter.Match) {
+	words := []string{match.Word}
+	if normalized := m.normalizedModel(); normalized != nil {
+		words = normalized.Originals(match.Word)
+	}
+
+	var categories []string
+	weight := 0
+	for _, word := range words {
+		for _, category := range m.Store.GetCategories(word) {
+			if !slices.Contains(categories, category) {
+				categories = append(categories, category)
+			}
+		}
+		weight = max(weight, m.Store.GetWeight(word))
+	}
+
+	match.Categories = categories
+	// 敏感日期命中已带有规则的权重
+	if match.Weight == 0 {
+		match.Weight = weight
+	}
+}
+
+// 返回在匹配前执行规范化的匹配器，未配置规范化时返回 nil
+func (m *Manager) normalizedModel() *filter.NormalizedModel {
+	model := m.Filter
+	if dated, ok := model.(*filter.DateModel); ok {
+		model = dated.Unwrap()
+	}
+	normalized, _ := model.(*filter.NormalizedModel)
+	return normalized
+}
+
+// FindAllByCategory 按分类汇总文本中的敏感词（每个分类下按出现顺序去重）
+// 属于多个分类的词会出现在每个分类下，未分类的词归入空字符串分类。
+func (m *Manager) FindAllByCategory(text string) map[string][]string {
+	res := make(map[string][]string)
+	seen := make(map[string]struct{})
+
+	for _, match := range m.FindAllMatches(text) {
+		if _, ok := seen[match.Word]; ok {
+			continue
+		}
+		seen[match.Word] = struct{}{}
+
+		if len(match.Categories) == 0 {
+			res[""] = append(res[""], match.Word)
+			continue
+		}
+		for _, category := range match.Categories {
+			res[category] = append(res[category], match.Word)
+		}
+	}
+
+	return res
 }
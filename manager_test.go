@@ -3,7 +3,15 @@ package go_sensitive_word
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
+	"unicode"
+
+	dfilter "github.com/zmexing/go-sensitive-word/filter"
+	"github.com/zmexing/go-sensitive-word/normalize"
 )
 
 // 敏感词检测
@@ -68,47 +76,576 @@ func TestFilter(t *testing.T) {
 	fmt.Printf("res6: %v \n", res6)
 }
 
-// 压力测试
-func BenchmarkIsSensitive(b *testing.B) {
+// 按分类查找敏感词
+func TestFindAllByCategory(t *testing.T) {
 	filter, err := NewFilter(
 		StoreOption{Type: StoreMemory},
-		FilterOption{Type: FilterDfa},
+		FilterOption{Type: FilterAC},
 	)
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
 
+	if err = filter.LoadDictEmbedCategory("ad", "加微信\n代开发票"); err != nil {
+		t.Fatal(err)
+	}
+	if err = filter.AddWordCategory("violence", "砍人", "代开发票"); err != nil {
+		t.Fatal(err)
+	}
+	if err = filter.AddWord("成小王"); err != nil {
+		t.Fatal(err)
+	}
+
+	text := "成小王说要砍人，加微信代开发票"
+
+	want := map[string][]string{
+		"":         {"成小王"},
+		"violence": {"砍人", "代开发票"},
+		"ad":       {"加微信", "代开发票"},
+	}
+	if got := filter.FindAllByCategory(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllByCategory = %v, want %v", got, want)
+	}
+
+	matches := filter.FindAllMatches(text)
+	if len(matches) != 4 || !reflect.DeepEqual(matches[3].Categories, []string{"ad", "violence"}) {
+		t.Errorf("FindAllMatches = %+v", matches)
+	}
+
+	res := filter.ReplaceFunc(text, func(match dfilter.Match) string {
+		if len(match.Categories) > 0 && match.Categories[0] == "ad" {
+			return "[广告]"
+		}
+		return "**"
+	})
+	if want := "**说要**，[广告][广告]"; res != want {
+		t.Errorf("ReplaceFunc = %q, want %q", res, want)
+	}
+
+	// 相互重叠的命中合并为一段，分类取并集
+	if err = filter.AddWordCategory("fraud", "发票"); err != nil {
+		t.Fatal(err)
+	}
+	res = filter.ReplaceFunc("代开发票", func(match dfilter.Match) string {
+		return fmt.Sprintf("[%s|%v]", match.Text, match.Categories)
+	})
+	if want := "[代开发票|[ad violence fraud]]"; res != want {
+		t.Errorf("ReplaceFunc = %q, want %q", res, want)
+	}
+}
+
+// 白名单短语
+func TestAllowWord(t *testing.T) {
+	filter, err := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterAC},
+	)
 	if err != nil {
-		log.Fatalf("敏感词服务启动失败, err:%v", err)
-		return
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
 	}
 
-	// 加载敏感词库
-	err = filter.LoadDictEmbed(
-		DictCovid19,
-		DictOther,
-		DictReactionary,
-		DictViolence,
-		DictPeopleLife,
-		DictPornography,
-		DictAdditional,
-		DictCorruption,
-		DictTemporaryTencent,
+	if err = filter.AddWord("市长"); err != nil {
+		t.Fatal(err)
+	}
+	if err = filter.LoadAllowDictEmbed("南京市长江大桥"); err != nil {
+		t.Fatal(err)
+	}
+
+	if filter.IsSensitive("南京市长江大桥") {
+		t.Error("IsSensitive inside allowed phrase = true")
+	}
+	if got := filter.FindAll("南京市长江大桥上站着市长"); !reflect.DeepEqual(got, []string{"市长"}) {
+		t.Errorf("FindAll = %v", got)
+	}
+	if got := filter.ReadAllowString(); !reflect.DeepEqual(got, []string{"南京市长江大桥"}) {
+		t.Errorf("ReadAllowString = %v", got)
+	}
+
+	if err = filter.DelAllowWord("南京市长江大桥"); err != nil {
+		t.Fatal(err)
+	}
+	if !filter.IsSensitive("南京市长江大桥") {
+		t.Error("IsSensitive after DelAllowWord = false")
+	}
+}
+
+// 噪声字符跳过
+func TestSkip(t *testing.T) {
+	filter, err := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDfa, Skip: DefaultSkip},
 	)
 	if err != nil {
-		log.Fatalf("加载词库发生了错误, err:%v", err)
-		return
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
 	}
 
-	err = filter.Store.AddWord("测试1", "测试2")
+	if err = filter.AddWordCategory(CategoryPolitical, "法轮功"); err != nil {
+		t.Fatal(err)
+	}
+
+	matches := filter.FindAllMatches("他说：法 轮-功！")
+	if len(matches) != 1 || matches[0].Word != "法轮功" || !reflect.DeepEqual(matches[0].Categories, []string{CategoryPolitical}) {
+		t.Errorf("FindAllMatches = %+v", matches)
+	}
+	if got, want := filter.Replace("他说：法 轮-功！", '*'), "他说：*****！"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+
+	// 白名单短语同样跳过噪声字符
+	if err = filter.AddWord("市长"); err != nil {
+		t.Fatal(err)
+	}
+	if err = filter.AddAllowWord("南京市长江大桥"); err != nil {
+		t.Fatal(err)
+	}
+	if filter.IsSensitive("南京市 长江大桥") {
+		t.Error("IsSensitive inside allowed phrase = true")
+	}
+}
+
+func TestMaxGap(t *testing.T) {
+	filter, err := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDfa, MaxGap: 1},
+	)
 	if err != nil {
-		fmt.Println(err)
-		return
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
 	}
 
-	sensitiveText := "小明微笑着对毒品销售说，我认为台湾国的人有点意思"
+	if err = filter.AddWord("法轮功", "习近平"); err != nil {
+		t.Fatal(err)
+	}
+	if err = filter.SetWordMaxGap(2, "习近平"); err != nil {
+		t.Fatal(err)
+	}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_ = filter.IsSensitive(sensitiveText)
+	if got, want := filter.Replace("法X轮X功，习某某近平", '*'), "*****，*****"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+	if filter.IsSensitive("法XX轮功") {
+		t.Error("IsSensitive matched a gap longer than MaxGap")
+	}
+
+	acFilter, err := NewFilter(StoreOption{Type: StoreMemory}, FilterOption{Type: FilterAC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = acFilter.SetWordMaxGap(1, "法轮功"); err == nil {
+		t.Error("SetWordMaxGap on FilterAC succeeded")
+	}
+}
+
+func TestNormalizers(t *testing.T) {
+	filter, err := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDfa, Normalizers: []normalize.Normalizer{normalize.Map(unicode.ToLower)}},
+	)
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+
+	if err = filter.AddWordWeight(5, "FUCK"); err != nil {
+		t.Fatal(err)
+	}
+	if err = filter.AddAllowWord("Fuck Off Club"); err != nil {
+		t.Fatal(err)
+	}
+
+	matches := filter.FindAllMatches("oh FuCk, fuck off club")
+	if len(matches) != 1 || matches[0].Word != "FUCK" || matches[0].Text != "FuCk" || matches[0].Weight != 5 {
+		t.Errorf("FindAllMatches = %+v", matches)
 	}
+	if got, want := filter.Replace("oh FuCk", '*'), "oh ****"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+}
+
+// 多个原词规范化后相同时，命中的分类取并集、权重取最大值
+func TestNormalizedCollision(t *testing.T) {
+	filter, err := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDfa, Normalizers: []normalize.Normalizer{normalize.NFKCFold}},
+	)
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+
+	if err = filter.AddWordCategory("ads", "FUCK"); err != nil {
+		t.Fatal(err)
+	}
+	if err = filter.AddWordWeight(9, "fuck"); err != nil {
+		t.Fatal(err)
+	}
+
+	matches := filter.FindAllMatches("oh Fuck")
+	if len(matches) != 1 || matches[0].Word != "FUCK" || !reflect.DeepEqual(matches[0].Categories, []string{"ads"}) || matches[0].Weight != 9 {
+		t.Errorf("FindAllMatches = %+v", matches)
+	}
+	if result := filter.Score("oh Fuck"); result.Score != 9 {
+		t.Errorf("Score = %+v", result)
+	}
+}
+
+func TestNFKCFold(t *testing.T) {
+	filter, err := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterAC, Normalizers: []normalize.Normalizer{normalize.NFKCFold}},
+	)
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+
+	if err = filter.LoadDictEmbed("Fuck\nＳＨＩＴ"); err != nil {
+		t.Fatal(err)
+	}
+
+	text := "ＦＵＣＫ ⓕⓤⓒⓚ 𝐟𝐮𝐜𝐤 FuCk shit"
+	if got, want := filter.FindAllCount(text), map[string]int{"Fuck": 4, "ＳＨＩＴ": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllCount = %v, want %v", got, want)
+	}
+	if got, want := filter.Replace(text, '*'), "**** **** **** **** ****"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+}
+
+// FilterOption 各模糊匹配与规范化选项的接入，以及不支持或非法的选项
+// 各选项的匹配行为由 filter、normalize 包中的测试覆盖，这里只检查 Manager 的组装：命中位置、原词与分类。
+func TestFilterOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		option  FilterOption
+		word    string // 以 CategoryPolitical 分类加入词库的词
+		text    string
+		matched string // 命中的原文片段，为空表示 NewFilter 应返回错误
+	}{
+		{"traditional simplified", FilterOption{Equivalences: []Equivalence{TraditionalSimplified}}, "台独", "反對臺獨", "臺獨"},
+		{"similar glyph and split characters", FilterOption{Equivalences: []Equivalence{SimilarGlyph}, SplitCharacters: true}, "习近平", "刁辶斤平！", "刁辶斤平"},
+		{"leetspeak", FilterOption{Normalizers: []normalize.Normalizer{normalize.NFKCFold}, Equivalences: []Equivalence{Leetspeak}}, "shit", "oh SH1T!", "SH1T"},
+		{"pinyin", FilterOption{Skip: DefaultSkip, Pinyin: PinyinAll}, "法轮功", "练fa lun gong。", "fa lun gong"},
+		{"homophone", FilterOption{Homophone: HomophoneAll}, "政治", "聊聊蒸纸", "蒸纸"},
+		{"max repeat", FilterOption{Mode: MatchLeftmostLongest, MaxRepeat: RepeatAll}, "傻逼", "傻傻傻逼逼!", "傻傻傻逼逼"},
+		{"confusables", FilterOption{Normalizers: []normalize.Normalizer{normalize.NFKCFold, normalize.Confusables}}, "fuck", "oh FU\u0421K", "FU\u0421K"},
+		{"strip marks", FilterOption{Normalizers: []normalize.Normalizer{normalize.StripMarks}}, "shit", "oh \u1e61\u1e25\u1ecb\u1e6b", "\u1e61\u1e25\u1ecb\u1e6b"},
+		{"numerals", FilterOption{Normalizers: []normalize.Normalizer{normalize.Numerals, normalize.NFKC}}, "六四", "纪念⑹⑷", "⑹⑷"},
+		{"dates", FilterOption{Dates: []DateRule{{Word: "六四", Month: 6, Day: 4}}}, "六四", "纪念五月三十五", "五月三十五"},
+		{"dates on FilterAC", FilterOption{Type: FilterAC, Dates: []DateRule{{Word: "六四", Month: 6, Day: 4}}}, "六四", "记得6/4吗", "6/4"},
+
+		{"skip on FilterAC", FilterOption{Type: FilterAC, Skip: DefaultSkip}, "", "", ""},
+		{"max gap on FilterAC", FilterOption{Type: FilterAC, MaxGap: 1}, "", "", ""},
+		{"equivalences on FilterDoubleArray", FilterOption{Type: FilterDoubleArray, Equivalences: []Equivalence{Leetspeak}}, "", "", ""},
+		{"pinyin on FilterAC", FilterOption{Type: FilterAC, Pinyin: PinyinAll}, "", "", ""},
+		{"homophone on FilterAC", FilterOption{Type: FilterAC, Homophone: 1}, "", "", ""},
+		{"split characters on FilterAC", FilterOption{Type: FilterAC, SplitCharacters: true}, "", "", ""},
+		{"max repeat on FilterAC", FilterOption{Type: FilterAC, MaxRepeat: 3}, "", "", ""},
+		{"invalid max gap", FilterOption{MaxGap: -1}, "", "", ""},
+		{"invalid homophone limit", FilterOption{Homophone: -2}, "", "", ""},
+		{"invalid max repeat", FilterOption{MaxRepeat: -2}, "", "", ""},
+		{"invalid date rule", FilterOption{Dates: []DateRule{{Month: 2, Day: 30}}}, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		filter, err := NewFilter(StoreOption{Type: StoreMemory}, tt.option)
+		if tt.matched == "" {
+			if err == nil {
+				t.Errorf("%s: NewFilter err = nil", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: 敏感词服务启动失败, err:%v", tt.name, err)
+		}
+		if err = filter.AddWordCategory(CategoryPolitical, tt.word); err != nil {
+			t.Fatal(err)
+		}
+
+		matches := filter.FindAllMatches(tt.text)
+		if len(matches) != 1 || matches[0].Word != tt.word || matches[0].Text != tt.matched || !reflect.DeepEqual(matches[0].Categories, []string{CategoryPolitical}) {
+			t.Errorf("%s: FindAllMatches = %+v", tt.name, matches)
+		}
+		want := strings.Replace(tt.text, tt.matched, strings.Repeat("*", len([]rune(tt.matched))), 1)
+		if got := filter.Replace(tt.text, '*'); got != want {
+			t.Errorf("%s: Replace = %q, want %q", tt.name, got, want)
+		}
+	}
+}
+
+// 加载全部内置词库并开启拼音匹配后，普通英文句子不应以拼音命中（如“we”“my”“gg”“she”“then”）
+// 词库中本身也有英文词，因此与不开启拼音匹配时的命中比较。
+func TestPinyinEnglish(t *testing.T) {
+	dicts := make([]string, 0, len(DictCategories))
+	for _, dict := range DictCategories {
+		dicts = append(dicts, dict)
+	}
+	plain, err := NewFilter(StoreOption{Type: StoreMemory}, FilterOption{Type: FilterDfa})
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+	pinyin, err := NewFilter(StoreOption{Type: StoreMemory}, FilterOption{Type: FilterDfa, Pinyin: PinyinAll})
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+	if err = plain.LoadDictEmbed(dicts...); err != nil {
+		t.Fatal(err)
+	}
+	if err = pinyin.LoadDictEmbed(dicts...); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{
+		"we are my gg friends",
+		"I think we should go to the park today, my friend.",
+		"Hello, how are you doing? It is a nice day and we can play games.",
+		"gg wp, see you in the next match",
+		"she said then any take",
+		"Let me know if you have any plans for the weekend.",
+		"The man can hang on a long line, then he went home to eat.",
+		"Open the door, we need to find out where she is going.",
+	} {
+		if got, want := pinyin.FindAll(text), plain.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAll(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+// 加载全部内置词库并开启同音字匹配后，日常用语不应以同音字命中（如“是个”命中“石戈”）
+// 日常用语中本身也有词库中的词（如“安全”），因此与不开启同音字匹配时的命中比较。
+func TestHomophoneEveryday(t *testing.T) {
+	dicts := make([]string, 0, len(DictCategories))
+	for _, dict := range DictCategories {
+		dicts = append(dicts, dict)
+	}
+	plain, err := NewFilter(StoreOption{Type: StoreMemory}, FilterOption{Type: FilterDfa})
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+	homophone, err := NewFilter(StoreOption{Type: StoreMemory}, FilterOption{Type: FilterDfa, Homophone: HomophoneAll})
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+	if err = plain.LoadDictEmbed(dicts...); err != nil {
+		t.Fatal(err)
+	}
+	if err = homophone.LoadDictEmbed(dicts...); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{
+		"今天是个好日子",
+		"我们明天一起去公园玩吧",
+		"这个问题很简单，大家都知道",
+		"他说这件事情已经处理好了",
+		"请大家注意安全，不要乱扔垃圾",
+		"公司明年的发展计划已经定下来了",
+		"孩子们在学校里学习很努力",
+		"这部电影讲的是一个感人的故事",
+		"会议改到下午三点，请准时参加",
+		"这里的风景非常美丽，值得一去",
+	} {
+		if got, want := homophone.FindAll(text), plain.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAll(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestIgnoreInvisible(t *testing.T) {
+	for _, filterType := range []uint32{FilterDfa, FilterAC, FilterDoubleArray} {
+		filter, err := NewFilter(
+			StoreOption{Type: StoreMemory},
+			FilterOption{Type: filterType, IgnoreInvisible: true},
+		)
+		if err != nil {
+			t.Fatalf("敏感词服务启动失败, err:%v", err)
+		}
+
+		if err = filter.AddWord("马斯克"); err != nil {
+			t.Fatal(err)
+		}
+
+		text := "你好马\u200b\u200c\u200d斯\ufeff克！"
+		if !filter.IsSensitive(text) {
+			t.Errorf("filter %d: IsSensitive = false", filterType)
+		}
+		if got, want := filter.Replace(text, '*'), "你好*******！"; got != want {
+			t.Errorf("filter %d: Replace = %q, want %q", filterType, got, want)
+		}
+		if got, want := filter.Remove(text), "你好！"; got != want {
+			t.Errorf("filter %d: Remove = %q, want %q", filterType, got, want)
+		}
+
+		// 白名单短语同样忽略不可见字符
+		if err = filter.AddWord("市长"); err != nil {
+			t.Fatal(err)
+		}
+		if err = filter.AddAllowWord("南京市长江大桥"); err != nil {
+			t.Fatal(err)
+		}
+		if filter.IsSensitive("南京市\u200b长江大桥") {
+			t.Errorf("filter %d: IsSensitive inside allowed phrase = true", filterType)
+		}
+	}
+}
+
+// 并发读写（配合 go test -race 使用）
+func TestConcurrentUpdate(t *testing.T) {
+	words := []string{"武汉", "武汉海鲜市场", "海鲜", "毒品", "台湾国", "测试1", "测试2"}
+	text := "小明对毒品销售说，我认为台湾国的人要去武汉海鲜市场测试1"
+
+	for _, filterType := range []uint32{FilterDfa, FilterAC, FilterDoubleArray} {
+		filter, err := NewFilter(
+			StoreOption{Type: StoreMemory},
+			FilterOption{Type: filterType},
+		)
+		if err != nil {
+			t.Fatalf("敏感词服务启动失败, err:%v", err)
+		}
+		if err = filter.LoadDictEmbed(DictCovid19, DictOther); err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		stop := make(chan struct{})
+
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					_ = filter.IsSensitive(text)
+					_ = filter.FindAll(text)
+					_ = filter.FindAllMatches(text)
+					_ = filter.Replace(text, '*')
+				}
+			}()
+		}
+
+		for i := 0; i < 200; i++ {
+			word := words[i%len(words)]
+			if i%3 == 2 {
+				err = filter.DelWord(word)
+			} else {
+				err = filter.AddWord(word)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		close(stop)
+		wg.Wait()
+
+		// 所有写操作完成后结果应与最终词库一致
+		if err = filter.AddWord(words...); err != nil {
+			t.Fatal(err)
+		}
+		if err = filter.DelWord("海鲜"); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"毒品", "台湾国", "武汉", "武汉海鲜市场", "测试1"}
+		if got := filter.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("filter type %d: FindAll = %v, want %v", filterType, got, want)
+		}
+	}
+}
+
+// Stats 穿过规范化与敏感日期的包装，只有 FilterDoubleArray 支持
+func TestStats(t *testing.T) {
+	filter, err := NewFilter(StoreOption{Type: StoreMemory}, FilterOption{
+		Type:        FilterDoubleArray,
+		Normalizers: []normalize.Normalizer{normalize.NFKCFold},
+		Dates:       []DateRule{{Word: "六四", Month: 6, Day: 4}},
+	})
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+	if err = filter.AddWord("武汉", "武汉海鲜市场"); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := filter.Stats()
+	if err != nil || stats.Words != 2 || stats.Nodes == 0 || stats.Bytes == 0 {
+		t.Errorf("Stats = %+v, %v", stats, err)
+	}
+
+	filter, err = NewFilter(StoreOption{Type: StoreMemory}, FilterOption{Type: FilterDfa})
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+	if _, err = filter.Stats(); err == nil {
+		t.Error("Stats on FilterDfa: err = nil")
+	}
+}
+
+// 压力测试：DFA 与双数组 Trie 加载相同词库后的查询耗时、分配次数与堆内存
+func BenchmarkIsSensitive(b *testing.B) {
+	for _, bench := range []struct {
+		name       string
+		filterType uint32
+	}{
+		{"dfa", FilterDfa},
+		{"double-array", FilterDoubleArray},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			before := heapAlloc()
+			filter, err := NewFilter(
+				StoreOption{Type: StoreMemory},
+				FilterOption{Type: bench.filterType},
+			)
+			if err != nil {
+				b.Fatalf("敏感词服务启动失败, err:%v", err)
+			}
+
+			// 加载敏感词库
+			err = filter.LoadDictEmbed(
+				DictCovid19,
+				DictOther,
+				DictReactionary,
+				DictViolence,
+				DictPeopleLife,
+				DictPornography,
+				DictAdditional,
+				DictCorruption,
+				DictTemporaryTencent,
+				DictNeteaseFE,
+				DictIllegalURL,
+			)
+			if err != nil {
+				b.Fatalf("加载词库发生了错误, err:%v", err)
+			}
+			if err = filter.Store.AddWord("测试1", "测试2"); err != nil {
+				b.Fatal(err)
+			}
+
+			sensitiveText := "小明微笑着对毒品销售说，我认为台湾国的人有点意思"
+
+			// 预先触发快照发布
+			_ = filter.IsSensitive(sensitiveText)
+			heap := heapAlloc() - before
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = filter.IsSensitive(sensitiveText)
+			}
+
+			// 记录加载词库后的堆内存，双数组另外记录节点数与数组本身的内存占用
+			b.ReportMetric(float64(heap), "heap-bytes")
+			if stats, err := filter.Stats(); err == nil {
+				b.ReportMetric(float64(stats.Nodes), "nodes")
+				b.ReportMetric(float64(stats.Bytes), "dict-bytes")
+			}
+		})
+	}
+}
+
+// GC 后的堆内存占用，用于估算加载词库后过滤器的内存
+func heapAlloc() int64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return int64(m.HeapAlloc)
 }
 
 // 压力测试
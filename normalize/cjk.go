@@ -0,0 +1,144 @@
+package normalize
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"golang.org/x/text/unicode/norm"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+//go:embed data/cjk_variants.txt
+var cjkVariants string
+
+var (
+	builtinCJKOnce  sync.Once
+	builtinCJKTable map[rune]rune
+)
+
+// CJKFold 汉字变体规范化器
+// 将康熙部首（如“⾦”U+2FA6）、部首补充中外形与独立汉字相同的部首（如“⻢”）、
+// 兼容汉字（如“金”U+F90A）以及常见异体字（如“羣”“峯”）折叠为统一的汉字。
+// 内置折叠表可通过 Load、LoadPath、Set 扩展，扩展应在加载词库之前完成。
+type CJKFold struct {
+	mu    sync.Mutex
+	table atomic.Pointer[map[rune]rune] // 变体字 -> 统一字，修改时整体复制
+}
+
+// NewCJKFold 创建使用内置折叠表的汉字变体规范化器
+func NewCJKFold() *CJKFold {
+	builtinCJKOnce.Do(func() {
+		builtinCJKTable = make(map[rune]rune)
+		if err := loadCJKVariants(strings.NewReader(cjkVariants), builtinCJKTable); err != nil {
+			panic(err)
+		}
+	})
+
+	f := &CJKFold{}
+	f.table.Store(&builtinCJKTable)
+	return f
+}
+
+// Fold 返回字符折叠后的统一字，不属于变体的字符原样返回
+func (f *CJKFold) Fold(r rune) rune {
+	if unified, ok := (*f.table.Load())[r]; ok {
+		return unified
+	}
+
+	// 康熙部首与兼容汉字按 Unicode 规范化数据折叠
+	if (r >= 0x2F00 && r <= 0x2FDF) || (r >= 0xF900 && r <= 0xFAFF) || (r >= 0x2F800 && r <= 0x2FA1F) {
+		var buf [utf8.UTFMax]byte
+		s := norm.NFKC.String(string(buf[:utf8.EncodeRune(buf[:], r)]))
+		if unified, size := utf8.DecodeRuneInString(s); size == len(s) {
+			return unified
+		}
+	}
+
+	return r
+}
+
+// Normalize 逐个字符折叠汉字变体
+func (f *CJKFold) Normalize(src []rune) ([]rune, []Span) {
+	return Map(f.Fold).Normalize(src)
+}
+
+// Set 添加或覆盖一条折叠规则
+func (f *CJKFold) Set(variant, unified rune) {
+	f.update(func(table map[rune]rune) error {
+		table[variant] = unified
+		return nil
+	})
+}
+
+// Load 从折叠表中读取规则，每行一个“变体字<TAB>统一字”，空行与 # 开头的行会被忽略
+func (f *CJKFold) Load(reader io.Reader) error {
+	return f.update(func(table map[rune]rune) error {
+		return loadCJKVariants(reader, table)
+	})
+}
+
+// LoadPath 从本地文件读取折叠规则
+func (f *CJKFold) LoadPath(paths ...string) error {
+	for _, path := range paths {
+		err := func(path string) error {
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer func(file *os.File) {
+				_ = file.Close()
+			}(file)
+
+			return f.Load(file)
+		}(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 复制当前的折叠表并修改后发布，出错时保持原表不变
+func (f *CJKFold) update(update func(table map[rune]rune) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	old := *f.table.Load()
+	table := make(map[rune]rune, len(old))
+	for variant, unified := range old {
+		table[variant] = unified
+	}
+	if err := update(table); err != nil {
+		return err
+	}
+
+	f.table.Store(&table)
+	return nil
+}
+
+// 解析折叠表
+func loadCJKVariants(reader io.Reader, table map[rune]rune) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || utf8.RuneCountInString(fields[0]) != 1 || utf8.RuneCountInString(fields[1]) != 1 {
+			return errors.New("invalid cjk variant line: " + line)
+		}
+		variant, _ := utf8.DecodeRuneInString(fields[0])
+		unified, _ := utf8.DecodeRuneInString(fields[1])
+		table[variant] = unified
+	}
+
+	return scanner.Err()
+}
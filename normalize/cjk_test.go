@@ -0,0 +1,44 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCJKFold(t *testing.T) {
+	fold := NewCJKFold()
+
+	tests := []struct {
+		src, want string
+	}{
+		{"⾦", "金"},             // 康熙部首
+		{"金", "金"},             // 兼容汉字
+		{"⻢⻋⻔", "马车门"},         // 部首补充
+		{"羣眾峯", "群衆峰"},         // 异体字
+		{"敏感词 abc", "敏感词 abc"}, // 其他字符不变
+	}
+	for _, tt := range tests {
+		if got := String(fold, tt.src); got != tt.want {
+			t.Errorf("Fold(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+
+	// 扩展折叠表不影响其他实例
+	if err := fold.Load(strings.NewReader("# 自定义\n乂\t义\n")); err != nil {
+		t.Fatal(err)
+	}
+	fold.Set('氵', '水')
+	if got, want := String(fold, "乂氵"), "义水"; got != want {
+		t.Errorf("Fold after Load = %q, want %q", got, want)
+	}
+	if got, want := String(NewCJKFold(), "乂"), "乂"; got != want {
+		t.Errorf("Fold of a new instance = %q, want %q", got, want)
+	}
+
+	if err := fold.Load(strings.NewReader("乂义\n")); err == nil {
+		t.Error("Load with an invalid line succeeded")
+	}
+	if got, want := String(fold, "乂"), "义"; got != want {
+		t.Errorf("Fold after a failed Load = %q, want %q", got, want)
+	}
+}
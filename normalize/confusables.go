@@ -0,0 +1,97 @@
+package normalize
+
+import (
+	"bufio"
+	_ "embed"
+	"golang.org/x/text/unicode/norm"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//go:embed data/confusables.txt
+var confusablesData string
+
+var (
+	confusablesOnce  sync.Once
+	confusablesTable map[rune][]rune // 易混淆字符 -> 骨架字符
+)
+
+// Confusables 易混淆字符骨架折叠（UTS #39 skeleton）
+// 西里尔字母“а”、希腊字母“ο”、数学字母“𝐚”等与拉丁字母外形相同的字符折叠为对应的 ASCII 字符，
+// 例如“fuсk”（其中“с”为西里尔字母）转换为“fuck”。骨架只用于比较，部分 ASCII 字符也会被折叠
+// （如“0”折叠为“O”、“m”折叠为“rn”），词库中的词经过同样的转换，因此不影响匹配。
+// 骨架区分大小写，通常放在 NFKCFold 之后使用。
+var Confusables Normalizer = confusables{}
+
+type confusables struct{}
+
+// 加载内置骨架表
+func loadConfusables() {
+	confusablesOnce.Do(func() {
+		confusablesTable = make(map[rune][]rune)
+		scanner := bufio.NewScanner(strings.NewReader(confusablesData))
+		for scanner.Scan() {
+			line, _, _ := strings.Cut(scanner.Text(), "#")
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			r, err := strconv.ParseUint(fields[0], 16, 32)
+			if err != nil {
+				panic(err)
+			}
+			var skeleton []rune
+			for _, field := range fields[1:] {
+				c, err := strconv.ParseUint(field, 16, 32)
+				if err != nil {
+					panic(err)
+				}
+				skeleton = append(skeleton, rune(c))
+			}
+			confusablesTable[rune(r)] = skeleton
+		}
+	})
+}
+
+// Normalize 按规范化边界将输入分段，每段先分解（NFD）、逐个字符替换为骨架后再分解
+// 一段输入产生的所有字符对应该段在输入中的区间，因此组合字符与其基本字符一同被替换或遮盖。
+func (confusables) Normalize(src []rune) ([]rune, []Span) {
+	loadConfusables()
+
+	dst := make([]rune, 0, len(src))
+	spans := make([]Span, 0, len(src))
+	s := string(src)
+	start := 0
+
+	for len(s) > 0 {
+		size := norm.NFD.NextBoundaryInString(s, true)
+		seg := s[:size]
+		s = s[size:]
+		span := Span{Start: start, End: start + utf8.RuneCountInString(seg)}
+		start = span.End
+
+		// 不在骨架表中的 ASCII 字符原样保留
+		if size == 1 && seg[0] < utf8.RuneSelf && confusablesTable[rune(seg[0])] == nil {
+			dst = append(dst, rune(seg[0]))
+			spans = append(spans, span)
+			continue
+		}
+
+		var b strings.Builder
+		for _, r := range norm.NFD.String(seg) {
+			if skeleton, ok := confusablesTable[r]; ok {
+				b.WriteString(string(skeleton))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		for _, r := range norm.NFD.String(b.String()) {
+			dst = append(dst, r)
+			spans = append(spans, span)
+		}
+	}
+
+	return dst, spans
+}
@@ -0,0 +1,35 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfusables(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"fu\u0441k", "fuck"},        // 西里尔字母 U+0441
+		{"sh\u0456t", "shit"},        // 西里尔字母 U+0456
+		{"g\u03bfd", "god"},          // 希腊字母 U+03BF
+		{"\U0001d41a\u0455s", "ass"}, // 数学字母 U+1D41A、西里尔字母 U+0455
+		{"敏感词", "敏感词"},
+	}
+	for _, tt := range tests {
+		// 骨架折叠后与同样折叠的原词相同即可匹配
+		if got, want := String(Confusables, tt.src), String(Confusables, tt.want); got != want {
+			t.Errorf("Confusables(%q) = %q, want %q", tt.src, got, want)
+		}
+	}
+
+	// 组合字符与基本字符对应同一段输入
+	dst, spans := Confusables.Normalize([]rune("\u0430\u0301b"))
+	if got, want := string(dst), "a\u0301b"; got != want {
+		t.Fatalf("Confusables = %q, want %q", got, want)
+	}
+	want := []Span{{Start: 0, End: 2}, {Start: 0, End: 2}, {Start: 2, End: 3}}
+	if !reflect.DeepEqual(spans, want) {
+		t.Errorf("spans = %+v, want %+v", spans, want)
+	}
+}
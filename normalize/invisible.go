@@ -0,0 +1,26 @@
+package normalize
+
+import "unicode"
+
+// IsInvisible 判断是否为不可见字符：Unicode 格式字符（Cf，包括零宽空格、零宽连接符、
+// 词连接符、软连字符、U+E0000 区块的标签字符等）以及变体选择符
+func IsInvisible(r rune) bool {
+	switch {
+	case r >= 0xFE00 && r <= 0xFE0F: // 变体选择符
+		return true
+	case r >= 0xE0100 && r <= 0xE01EF: // 变体选择符补充
+		return true
+	case r == 0x034F: // 组合用字形连接符
+		return true
+	}
+
+	return unicode.Is(unicode.Cf, r)
+}
+
+// StripInvisible 删除所有不可见字符（见 IsInvisible）的规范化器
+var StripInvisible Normalizer = Map(func(r rune) rune {
+	if IsInvisible(r) {
+		return -1
+	}
+	return r
+})
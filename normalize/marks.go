@@ -0,0 +1,61 @@
+package normalize
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StripMarks 删除组合符号：先分解（NFD），再删除非间距符号（Mn）与封闭符号（Me），只保留基本字符
+// 例如“ṡḣịṫ”转换为“shit”，带删除线的“f̶u̶c̶k̶”转换为“fuck”，“café”转换为“cafe”。
+// 基本字符对应原文中包括其组合符号在内的整个字符，因此 Replace 会连同组合符号一起遮盖。
+var StripMarks Normalizer = stripMarks{}
+
+type stripMarks struct{}
+
+// 判断是否为需要删除的组合符号
+func isMark(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// Normalize 按规范化边界将输入分段（基本字符与其后的组合符号为一段），删除段中的组合符号
+// 段中没有组合符号时原样保留，剩余的字符对应整段输入。
+func (stripMarks) Normalize(src []rune) ([]rune, []Span) {
+	dst := make([]rune, 0, len(src))
+	spans := make([]Span, 0, len(src))
+	s := string(src)
+	start := 0
+
+	for len(s) > 0 {
+		size := norm.NFD.NextBoundaryInString(s, true)
+		seg := s[:size]
+		s = s[size:]
+		span := Span{Start: start, End: start + utf8.RuneCountInString(seg)}
+		start = span.End
+
+		// ASCII 字符不含组合符号
+		if size == 1 && seg[0] < utf8.RuneSelf {
+			dst = append(dst, rune(seg[0]))
+			spans = append(spans, span)
+			continue
+		}
+
+		decomposed := []rune(norm.NFD.String(seg))
+		base := decomposed[:0]
+		for _, r := range decomposed {
+			if !isMark(r) {
+				base = append(base, r)
+			}
+		}
+		out := []rune(seg)
+		if len(base) < len(decomposed) {
+			out = []rune(norm.NFC.String(string(base)))
+		}
+		for _, r := range out {
+			dst = append(dst, r)
+			spans = append(spans, span)
+		}
+	}
+
+	return dst, spans
+}
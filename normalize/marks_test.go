@@ -0,0 +1,35 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStripMarks(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"ṡḣịṫ", "shit"},                         // 带点的拉丁字母
+		{"f\u0336u\u0336c\u0336k\u0336", "fuck"}, // 删除线
+		{"f\u0489u\u20dd", "fu"},                 // 封闭符号
+		{"café naïve", "cafe naive"},             // 预组合字符
+		{"\u0336a", "a"},                         // 开头孤立的组合符号
+		{"敏感词 가\u0336", "敏感词 가"},                 // 谚文音节分解后重新组合
+	}
+	for _, tt := range tests {
+		if got := String(StripMarks, tt.src); got != tt.want {
+			t.Errorf("StripMarks(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+
+	// 基本字符对应包括组合符号在内的整个字符
+	dst, spans := StripMarks.Normalize([]rune("a\u0336\u0336b"))
+	if got, want := string(dst), "ab"; got != want {
+		t.Fatalf("StripMarks = %q, want %q", got, want)
+	}
+	want := []Span{{Start: 0, End: 3}, {Start: 3, End: 4}}
+	if !reflect.DeepEqual(spans, want) {
+		t.Errorf("spans = %+v, want %+v", spans, want)
+	}
+}
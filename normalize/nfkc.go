@@ -0,0 +1,64 @@
+package normalize
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+	"unicode/utf8"
+)
+
+// NFKC 兼容等价规范化：全角字符、带圈字符、数学字母数字符号、连字等转换为标准形式
+// 例如“ＦＵＣＫ”“ⓕⓤⓒⓚ”“𝐟𝐮𝐜𝐤”分别转换为“FUCK”“fuck”“fuck”。
+var NFKC Normalizer = nfkc{}
+
+// NFKCFold 在 NFKC 的基础上做大小写折叠（NFKC_Casefold），“FuCk”“ＦＵＣＫ”均转换为“fuck”
+// 词库中的词与待查文本经过同样的转换，因此大小写、全角半角不同的写法都能命中。
+var NFKCFold Normalizer = nfkc{fold: true}
+
+// NFKC 规范化器，fold 为 true 时同时做大小写折叠
+type nfkc struct {
+	fold bool
+}
+
+// Normalize 按规范化边界将输入分段转换，一段输入产生的所有字符对应该段在输入中的区间
+// 例如基本字符与其后的组合字符为一段，转换后的字符对应整段。
+func (n nfkc) Normalize(src []rune) ([]rune, []Span) {
+	var caser cases.Caser // Caser 有内部状态，不能在多个协程间共享
+	if n.fold {
+		caser = cases.Fold()
+	}
+
+	dst := make([]rune, 0, len(src))
+	spans := make([]Span, 0, len(src))
+	s := string(src)
+	start := 0
+
+	for len(s) > 0 {
+		size := norm.NFKC.NextBoundaryInString(s, true)
+		seg := s[:size]
+		s = s[size:]
+		span := Span{Start: start, End: start + utf8.RuneCountInString(seg)}
+		start = span.End
+
+		// ASCII 字符无需规范化，只需折叠大小写
+		if size == 1 && seg[0] < utf8.RuneSelf {
+			r := rune(seg[0])
+			if n.fold && r >= 'A' && r <= 'Z' {
+				r += 'a' - 'A'
+			}
+			dst = append(dst, r)
+			spans = append(spans, span)
+			continue
+		}
+
+		out := norm.NFKC.String(seg)
+		if n.fold {
+			out = norm.NFKC.String(caser.String(out))
+		}
+		for _, r := range out {
+			dst = append(dst, r)
+			spans = append(spans, span)
+		}
+	}
+
+	return dst, spans
+}
@@ -0,0 +1,40 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNFKCFold(t *testing.T) {
+	tests := []struct {
+		src  string
+		nfkc string
+		fold string
+	}{
+		{"ＦＵＣＫ", "FUCK", "fuck"},
+		{"ⓕⓤⓒⓚ", "fuck", "fuck"},
+		{"𝐟𝐮𝐜𝐤", "fuck", "fuck"},
+		{"FuCk", "FuCk", "fuck"},
+		{"ﬁne Straße", "fine Straße", "fine strasse"},
+		{"１２３", "123", "123"},
+		{"敏感词", "敏感词", "敏感词"},
+	}
+	for _, tt := range tests {
+		if got := String(NFKC, tt.src); got != tt.nfkc {
+			t.Errorf("NFKC(%q) = %q, want %q", tt.src, got, tt.nfkc)
+		}
+		if got := String(NFKCFold, tt.src); got != tt.fold {
+			t.Errorf("NFKCFold(%q) = %q, want %q", tt.src, got, tt.fold)
+		}
+	}
+
+	// 一个输入字符展开为多个字符，或与组合字符合成为一个字符时，区间对应整段输入
+	dst, spans := NFKCFold.Normalize([]rune("ﬁＥ\u0301"))
+	if got, want := string(dst), "fié"; got != want {
+		t.Fatalf("NFKCFold = %q, want %q", got, want)
+	}
+	want := []Span{{Start: 0, End: 1}, {Start: 0, End: 1}, {Start: 1, End: 3}}
+	if !reflect.DeepEqual(spans, want) {
+		t.Errorf("spans = %+v, want %+v", spans, want)
+	}
+}
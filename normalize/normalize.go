@@ -0,0 +1,89 @@
+package normalize
+
+// Span 规范化后的字符在原文中对应的区间（rune 下标，左闭右开）
+type Span struct {
+	Start int
+	End   int
+}
+
+// Normalizer 将文本转换为用于匹配的规范形式
+// Normalize 返回规范化后的字符，以及每个输出字符在输入中对应的区间。
+// 区间非空且按输出顺序非递减；一个输入片段可以产生多个输出字符（共享同一区间），也可以被整体删除（不产生输出）。
+type Normalizer interface {
+	Normalize(src []rune) (dst []rune, spans []Span)
+}
+
+// Pipeline 按顺序依次执行的规范化流水线，输出区间始终对应最初的输入
+type Pipeline []Normalizer
+
+// Normalize 依次执行流水线中的规范化器，并将每一步的区间映射回最初的输入
+func (p Pipeline) Normalize(src []rune) ([]rune, []Span) {
+	dst, spans := src, identity(len(src))
+
+	for _, n := range p {
+		next, nextSpans := n.Normalize(dst)
+		for i, span := range nextSpans {
+			nextSpans[i] = compose(spans, span)
+		}
+		dst, spans = next, nextSpans
+	}
+
+	return dst, spans
+}
+
+// Map 逐个字符转换的规范化器，返回负数表示删除该字符
+type Map func(r rune) rune
+
+// Normalize 逐个转换字符，每个输出字符对应输入中的同一个字符
+func (f Map) Normalize(src []rune) ([]rune, []Span) {
+	dst := make([]rune, 0, len(src))
+	spans := make([]Span, 0, len(src))
+
+	for i, r := range src {
+		if r = f(r); r < 0 {
+			continue
+		}
+		dst = append(dst, r)
+		spans = append(spans, Span{Start: i, End: i + 1})
+	}
+
+	return dst, spans
+}
+
+// String 返回文本规范化后的结果，用于规范化词库中的词
+func String(n Normalizer, s string) string {
+	dst, _ := n.Normalize([]rune(s))
+	return string(dst)
+}
+
+// Text 规范化后的文本，记录了与原文之间的位置映射
+type Text struct {
+	Runes []rune // 规范化后的字符
+	spans []Span // 每个字符在原文中对应的区间
+}
+
+// NewText 对原文执行规范化
+func NewText(n Normalizer, src []rune) *Text {
+	dst, spans := n.Normalize(src)
+	return &Text{Runes: dst, spans: spans}
+}
+
+// Span 将规范化文本中的非空区间 [start, end) 映射回原文中的 rune 区间
+// 规范化时由同一个原文片段产生的字符只要有一个落在区间内，整个片段都会被包含。
+func (t *Text) Span(start, end int) Span {
+	return Span{Start: t.spans[start].Start, End: t.spans[end-1].End}
+}
+
+// 每个字符对应自身的区间
+func identity(n int) []Span {
+	spans := make([]Span, n)
+	for i := range spans {
+		spans[i] = Span{Start: i, End: i + 1}
+	}
+	return spans
+}
+
+// 将相对于上一步输出的区间映射为相对于最初输入的区间
+func compose(prev []Span, span Span) Span {
+	return Span{Start: prev[span.Start].Start, End: prev[span.End-1].End}
+}
@@ -0,0 +1,61 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+// 将连字“ﬁ”展开为“fi”的测试用规范化器
+type ligature struct{}
+
+func (ligature) Normalize(src []rune) ([]rune, []Span) {
+	var dst []rune
+	var spans []Span
+	for i, r := range src {
+		span := Span{Start: i, End: i + 1}
+		if r == 'ﬁ' {
+			dst = append(dst, 'f', 'i')
+			spans = append(spans, span, span)
+			continue
+		}
+		dst = append(dst, r)
+		spans = append(spans, span)
+	}
+	return dst, spans
+}
+
+func TestPipeline(t *testing.T) {
+	strip := Map(func(r rune) rune {
+		if r == '\u200b' {
+			return -1
+		}
+		return r
+	})
+	pipeline := Pipeline{strip, ligature{}, Map(unicode.ToLower)}
+
+	src := []rune("A\u200bﬁX")
+	text := NewText(pipeline, src)
+	if got, want := string(text.Runes), "afix"; got != want {
+		t.Fatalf("Runes = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		start, end int
+		want       Span
+	}{
+		{0, 1, Span{Start: 0, End: 1}}, // a
+		{0, 2, Span{Start: 0, End: 3}}, // af，包含被删除的零宽空格
+		{2, 3, Span{Start: 2, End: 3}}, // i 属于连字“ﬁ”
+		{2, 4, Span{Start: 2, End: 4}}, // ix
+	}
+	for _, tt := range tests {
+		if got := text.Span(tt.start, tt.end); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Span(%d, %d) = %+v, want %+v", tt.start, tt.end, got, tt.want)
+		}
+	}
+
+	if got, want := String(pipeline, "FﬁLE"), "ffile"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
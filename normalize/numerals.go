@@ -0,0 +1,88 @@
+package normalize
+
+import "strconv"
+
+// Numerals 数字写法折叠：汉字小写数字（“六四”）、大写数字（“陆肆”）、带圈与带括号数字（“⑥④”“⑹⑷”）、
+// 全角数字（“６４”）等转换为 ASCII 数字，例如“陆肆”“⑥④”“６４”均转换为“64”。
+// 只转换表示单个数字的字符，“十”“百”等表示数位的字不转换；“⑩”“㉑”等带圈的多位数转换为多个数字，共享原文中的同一个字符。
+// NFKC 会将“⑹”“⒍”转换为“(6)”“6.”，因此应放在 NFKC 之前使用。
+var Numerals Normalizer = numerals{}
+
+type numerals struct{}
+
+// 汉字数字及其大写、异体写法
+var chineseDigits = map[rune]int{
+	'〇': 0, '零': 0, '○': 0,
+	'一': 1, '壹': 1, '幺': 1,
+	'二': 2, '贰': 2, '貳': 2, '两': 2, '兩': 2,
+	'三': 3, '叁': 3, '參': 3,
+	'四': 4, '肆': 4,
+	'五': 5, '伍': 5,
+	'六': 6, '陆': 6, '陸': 6,
+	'七': 7, '柒': 7,
+	'八': 8, '捌': 8,
+	'九': 9, '玖': 9,
+}
+
+// 返回字符表示的数值，不是数字写法时返回 -1
+func numeralValue(r rune) int {
+	switch {
+	case '0' <= r && r <= '9':
+		return int(r - '0')
+	case '０' <= r && r <= '９': // 全角数字
+		return int(r - '０')
+	case '①' <= r && r <= '⑳': // 带圈数字 1-20
+		return int(r-'①') + 1
+	case '⑴' <= r && r <= '⒇': // 带括号数字 1-20
+		return int(r-'⑴') + 1
+	case '⒈' <= r && r <= '⒛': // 带句点数字 1-20
+		return int(r-'⒈') + 1
+	case r == '⓪' || r == '⓿':
+		return 0
+	case '⓫' <= r && r <= '⓴': // 黑底带圈数字 11-20
+		return int(r-'⓫') + 11
+	case '⓵' <= r && r <= '⓾': // 双圈数字 1-10
+		return int(r-'⓵') + 1
+	case '❶' <= r && r <= '❿': // 黑底带圈数字 1-10
+		return int(r-'❶') + 1
+	case '➀' <= r && r <= '➉': // 无衬线带圈数字 1-10
+		return int(r-'➀') + 1
+	case '➊' <= r && r <= '➓': // 无衬线黑底带圈数字 1-10
+		return int(r-'➊') + 1
+	case '㉑' <= r && r <= '㉟': // 带圈数字 21-35
+		return int(r-'㉑') + 21
+	case '㊱' <= r && r <= '㊿': // 带圈数字 36-50
+		return int(r-'㊱') + 36
+	case '㈠' <= r && r <= '㈩': // 带括号汉字数字一至十
+		return int(r-'㈠') + 1
+	case '㊀' <= r && r <= '㊉': // 带圈汉字数字一至十
+		return int(r-'㊀') + 1
+	}
+	if v, ok := chineseDigits[r]; ok {
+		return v
+	}
+
+	return -1
+}
+
+// Normalize 将数字写法转换为 ASCII 数字，其他字符原样保留
+func (numerals) Normalize(src []rune) ([]rune, []Span) {
+	dst := make([]rune, 0, len(src))
+	spans := make([]Span, 0, len(src))
+
+	for i, r := range src {
+		span := Span{Start: i, End: i + 1}
+		v := numeralValue(r)
+		if v < 0 {
+			dst = append(dst, r)
+			spans = append(spans, span)
+			continue
+		}
+		for _, d := range strconv.Itoa(v) {
+			dst = append(dst, d)
+			spans = append(spans, span)
+		}
+	}
+
+	return dst, spans
+}
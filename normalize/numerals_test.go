@@ -0,0 +1,39 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumerals(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"六四", "64"},
+		{"陆肆", "64"},
+		{"⑥④", "64"},
+		{"⑹⑷", "64"},
+		{"６４", "64"},
+		{"陆4", "64"}, // 混合写法
+		{"幺三八零〇两", "138002"},
+		{"❻㈣⒍➃", "6464"},
+		{"三十五", "3十5"}, // 数位不转换
+		{"敏感词", "敏感词"},
+	}
+	for _, tt := range tests {
+		if got := String(Numerals, tt.src); got != tt.want {
+			t.Errorf("Numerals(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+
+	// 多位数共享原文中的同一个字符
+	dst, spans := Numerals.Normalize([]rune("a⑫"))
+	if got, want := string(dst), "a12"; got != want {
+		t.Fatalf("Numerals = %q, want %q", got, want)
+	}
+	want := []Span{{Start: 0, End: 1}, {Start: 1, End: 2}, {Start: 1, End: 2}}
+	if !reflect.DeepEqual(spans, want) {
+		t.Errorf("spans = %+v, want %+v", spans, want)
+	}
+}
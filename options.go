@@ -1,6 +1,11 @@
 package go_sensitive_word
 
-import _ "embed"
+import (
+	_ "embed"
+
+	"github.com/zmexing/go-sensitive-word/filter"
+	"github.com/zmexing/go-sensitive-word/normalize"
+)
 
 // StoreMemory 类型常量定义
 // 当前仅支持内存存储（StoreMemory），后续可扩展为 Redis、文件存储等。
@@ -9,23 +14,118 @@ const (
 )
 
 // FilterDfa 类型常量定义
-// 当前仅支持 DFA 算法（FilterDfa），后续可支持 Trie、AC自动机、正则等。
+// 当前支持 DFA 算法（FilterDfa）、AC 自动机（FilterAC）与双数组 Trie（FilterDoubleArray），后续可支持正则等。
+const (
+	FilterDfa         = iota // DFA 敏感词过滤算法（默认）
+	FilterAC                 // AC 自动机敏感词过滤算法，长文本下单次线性扫描
+	FilterDoubleArray        // 双数组 Trie 敏感词过滤算法，内存占用低且不含指针
+)
+
+// MatchMode 类型常量定义
+// 决定相互重叠的命中（如“武汉”与“武汉海鲜市场”）如何处理，所有查找、替换、移除方法统一遵循该策略。
+const (
+	MatchAllOverlapping   = filter.MatchAllOverlapping   // 返回所有命中，包括相互重叠的命中（默认）
+	MatchLeftmostLongest  = filter.MatchLeftmostLongest  // 从左到右依次取最长命中，结果互不重叠
+	MatchLeftmostShortest = filter.MatchLeftmostShortest // 从左到右依次取最短命中，结果互不重叠
+)
+
+// PinyinMode 类型常量定义
+// 决定 FilterDfa 是否以拼音匹配词库中的汉字词，如“falungong”“flg”“法lun功”均命中“法轮功”。
 const (
-	FilterDfa = iota // DFA 敏感词过滤算法（默认）
+	PinyinOff     = filter.PinyinOff     // 关闭拼音匹配（默认）
+	PinyinFull    = filter.PinyinFull    // 全拼匹配
+	PinyinInitial = filter.PinyinInitial // 首字母匹配
+	PinyinAll     = filter.PinyinAll     // 全拼与首字母混合匹配
 )
 
+// HomophoneAll 同音字匹配时不限制以同音字代替的字数，可用作 FilterOption.Homophone
+const HomophoneAll = filter.HomophoneAll
+
+// RepeatAll 重复字符匹配时不限制连续重复的次数，可用作 FilterOption.MaxRepeat
+const RepeatAll = filter.RepeatAll
+
+// DateRule 敏感日期规则，声明月、日（可选年份）后识别各种常见写法，可用于 FilterOption.Dates
+type DateRule = filter.DateRule
+
 // StoreOption 定义了词库存储的配置选项
 // Type 字段用于指定词库的存储实现方式，如内存、Redis、文件等。
 type StoreOption struct {
 	Type uint32 // 存储类型标识，例如 StoreMemory
 }
 
+// DefaultSkip 默认的噪声字符集合：空白、标点与符号（含大部分 emoji），可用作 FilterOption.Skip
+var DefaultSkip = filter.DefaultSkip
+
+// Equivalence 字符等价关系：返回匹配时可以代替输入字符与词中字符比较的其他字符
+type Equivalence = filter.Equivalence
+
+// TraditionalSimplified 繁简等价，可用于 FilterOption.Equivalences，使繁体写法也能命中简体词库
+var TraditionalSimplified Equivalence = filter.TraditionalSimplified
+
+// SimilarGlyph 形近字等价，可用于 FilterOption.Equivalences，使“自已”“周未”这类形近字写法也能命中
+var SimilarGlyph Equivalence = filter.SimilarGlyph
+
+// Leetspeak 数字、符号代替字母的等价关系（1→i/l、0→o、@→a/u、$→s 等），可用于 FilterOption.Equivalences，大写写法需配合 normalize.NFKCFold
+var Leetspeak Equivalence = filter.Leetspeak
+
+// Substitution 替换类：输入字符 -> 匹配时可以代替的词中字符，通过 Equivalence 方法得到字符等价关系
+type Substitution = filter.Substitution
+
+// LeetspeakTable 返回内置 leetspeak 替换类的副本，可修改后通过 Equivalence 方法使用
+var LeetspeakTable = filter.LeetspeakTable
+
 // FilterOption 定义了敏感词过滤器的配置选项
-// Type 字段用于指定过滤算法的实现方式，如 DFA、Trie、正则等。
+// Type 字段用于指定过滤算法的实现方式，如 DFA、Trie、正则等；Mode 字段用于指定重叠命中的处理策略。
+// Skip、MaxGap、Equivalences、Pinyin、Homophone、SplitCharacters、MaxRepeat 为模糊匹配选项，仅 FilterDfa 支持。
 type FilterOption struct {
-	Type uint32 // 过滤器类型标识，例如 FilterDfa
+	Type uint32           // 过滤器类型标识，例如 FilterDfa、FilterAC、FilterDoubleArray
+	Mode filter.MatchMode // 重叠命中处理策略，例如 MatchLeftmostLongest，默认 MatchAllOverlapping
+	Skip func(rune) bool  // 噪声字符判定函数，命中词内部的噪声字符会被跳过，例如 DefaultSkip
+	// 词中相邻字符之间最多允许插入的任意字符数，默认 0 表示必须相邻
+	MaxGap int
+	// 规范化流水线，按顺序作用于词库中的词与待查文本，命中位置仍对应原文
+	Normalizers []normalize.Normalizer
+	// 忽略零宽字符、软连字符、变体选择符、标签字符等不可见字符，使“马\u200b斯\u200b克”仍能命中
+	IgnoreInvisible bool
+	// 匹配时使用的字符等价关系，例如 TraditionalSimplified
+	Equivalences []Equivalence
+	// 拼音匹配方式，例如 PinyinAll，默认 PinyinOff；“fa lun gong”这类音节之间带空格的写法需同时设置 Skip
+	Pinyin filter.PinyinMode
+	// 同音字匹配：每个命中中最多允许以同音字代替的字数，HomophoneAll 表示不限，默认 0 关闭
+	Homophone int
+	// 识别拆字写法，相邻的两个部件可以合成一个字，如“氵去”合成“法”、“弓长”合成“张”
+	SplitCharacters bool
+	// 重复字符匹配：词中一个字符最多可以匹配的连续重复字符数，使“傻傻傻逼逼”“fuuuuuck”仍能命中，RepeatAll 表示不限，默认 0 关闭
+	MaxRepeat int
+	// 敏感日期规则：识别“6月4日”“五月三十五”“6/4”“8平方”等写法，命中与词库命中一起返回
+	Dates []DateRule
 }
 
+// 内置词库分类标签，与下方内置词库一一对应
+// 可配合 LoadDictEmbedCategory 加载，命中结果会携带对应的分类，便于按分类制定不同的处理策略。
+//
+// 使用示例：
+//
+//	err := manager.LoadDictEmbedCategory(CategoryPolitical, DictPolitical)
+const (
+	CategoryCovid19          = "covid19"           // COVID-19词库
+	CategoryGFWAdditional    = "gfw_additional"    // GFW补充词库
+	CategoryOther            = "other"             // 其他词库
+	CategoryReactionary      = "reactionary"       // 反动词库
+	CategoryAdvertisement    = "advertisement"     // 广告类型
+	CategoryPolitical        = "political"         // 政治类型
+	CategoryViolence         = "violence"          // 暴恐词库
+	CategoryPeopleLife       = "people_life"       // 民生词库
+	CategoryGunExplosion     = "gun_explosion"     // 涉枪涉爆
+	CategoryNeteaseFE        = "netease_fe"        // 网易前端过滤敏感词库
+	CategorySexual           = "sexual"            // 色情类型
+	CategoryPornography      = "pornography"       // 色情词库
+	CategoryAdditional       = "additional"        // 补充词库
+	CategoryCorruption       = "corruption"        // 贪腐词库
+	CategoryTemporaryTencent = "temporary_tencent" // 零时-Tencent
+	CategoryIllegalURL       = "illegal_url"       // 非法网址
+)
+
 // 内置敏感词词库（通过 go:embed 嵌入编译时）
 // 这些变量可直接用于调用 LoadDictEmbed 加载内置词库内容，无需读取本地文件。
 // 可按需选择加载不同类别的敏感词，例如政治类、暴恐类、色情类、贪腐类等。
@@ -67,3 +167,29 @@ var (
 	//go:embed text/非法网址.txt
 	DictIllegalURL string
 )
+
+// DictCategories 内置词库分类标签到词库内容的映射，便于按分类批量加载
+//
+// 使用示例：
+//
+//	for category, dict := range DictCategories {
+//		err := manager.LoadDictEmbedCategory(category, dict)
+//	}
+var DictCategories = map[string]string{
+	CategoryCovid19:          DictCovid19,
+	CategoryGFWAdditional:    DictGFWAdditional,
+	CategoryOther:            DictOther,
+	CategoryReactionary:      DictReactionary,
+	CategoryAdvertisement:    DictAdvertisement,
+	CategoryPolitical:        DictPolitical,
+	CategoryViolence:         DictViolence,
+	CategoryPeopleLife:       DictPeopleLife,
+	CategoryGunExplosion:     DictGunExplosion,
+	CategoryNeteaseFE:        DictNeteaseFE,
+	CategorySexual:           DictSexual,
+	CategoryPornography:      DictPornography,
+	CategoryAdditional:       DictAdditional,
+	CategoryCorruption:       DictCorruption,
+	CategoryTemporaryTencent: DictTemporaryTencent,
+	CategoryIllegalURL:       DictIllegalURL,
+}
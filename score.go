@@ -0,0 +1,93 @@
+package go_sensitive_word
+
+import (
+	"github.com/zmexing/go-sensitive-word/filter"
+	"math"
+)
+
+// Decision 风险评分后的处理决策
+type Decision int
+
+const (
+	DecisionPass   Decision = iota // 放行
+	DecisionReview                 // 转人工审核
+	DecisionBlock                  // 拦截
+)
+
+// String 返回决策的可读名称
+func (d Decision) String() string {
+	switch d {
+	case DecisionPass:
+		return "pass"
+	case DecisionReview:
+		return "review"
+	case DecisionBlock:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// ScoreOption 定义了风险评分的配置选项
+// 同一个词第 n 次命中计 权重 × RepeatFactor^(n-1) 分：
+// RepeatFactor 为 0 时重复命中不再计分，为 1 时每次命中计相同分数，大于 1 时重复命中逐次加重。
+type ScoreOption struct {
+	ReviewThreshold float64 // 总分达到该值时转人工审核
+	BlockThreshold  float64 // 总分达到该值时直接拦截
+	RepeatFactor    float64 // 重复命中的递增系数
+	MaxRepeat       int     // 同一个词最多计分的命中次数，0 表示不限制
+}
+
+// DefaultScoreOption 默认的风险评分配置
+var DefaultScoreOption = ScoreOption{
+	ReviewThreshold: 1,
+	BlockThreshold:  10,
+	RepeatFactor:    1,
+}
+
+// ScoreResult 是一段文本的风险评分结果
+type ScoreResult struct {
+	Score    float64        // 总分
+	Decision Decision       // 处理决策
+	Matches  []filter.Match // 参与评分的命中（附带分类与权重）
+}
+
+// SetScoreOption 设置风险评分配置
+func (m *Manager) SetScoreOption(option ScoreOption) {
+	m.scoreOption.Store(&option)
+}
+
+// Score 累加文本中命中词的权重得到风险分，并按阈值给出放行、审核或拦截的决策
+func (m *Manager) Score(text string) ScoreResult {
+	option := m.scoreOption.Load()
+	if option == nil {
+		option = &DefaultScoreOption
+	}
+
+	matches := m.FindAllMatches(text)
+	repeats := make(map[string]int, len(matches))
+	score := 0.0
+
+	for _, match := range matches {
+		n := repeats[match.Word]
+		repeats[match.Word] = n + 1
+		if option.MaxRepeat > 0 && n >= option.MaxRepeat {
+			continue
+		}
+		score += float64(match.Weight) * math.Pow(option.RepeatFactor, float64(n))
+	}
+
+	decision := DecisionPass
+	switch {
+	case score >= option.BlockThreshold:
+		decision = DecisionBlock
+	case score >= option.ReviewThreshold:
+		decision = DecisionReview
+	}
+
+	return ScoreResult{
+		Score:    score,
+		Decision: decision,
+		Matches:  matches,
+	}
+}
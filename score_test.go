@@ -0,0 +1,69 @@
+package go_sensitive_word
+
+import "testing"
+
+// 风险评分
+func TestScore(t *testing.T) {
+	filter, err := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterAC},
+	)
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+
+	if err = filter.LoadDictEmbed("赌博\t3\n成小王"); err != nil {
+		t.Fatal(err)
+	}
+	if err = filter.AddWordWeight(5, "毒品"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := filter.GetWeight("成小王"); got != 1 {
+		t.Errorf("GetWeight(成小王) = %d, want 1", got)
+	}
+
+	tests := []struct {
+		name     string
+		option   ScoreOption
+		text     string
+		score    float64
+		decision Decision
+	}{
+		{"pass", DefaultScoreOption, "今天天气不错", 0, DecisionPass},
+		{"review", DefaultScoreOption, "成小王去赌博", 4, DecisionReview},
+		{"block", DefaultScoreOption, "成小王去赌博，还碰了毒品，又去赌博", 1 + 3 + 5 + 3, DecisionBlock},
+		{"once", ScoreOption{ReviewThreshold: 1, BlockThreshold: 10}, "赌博赌博赌博", 3, DecisionReview},
+		{"escalate", ScoreOption{ReviewThreshold: 1, BlockThreshold: 20, RepeatFactor: 2}, "赌博赌博赌博", 3 + 6 + 12, DecisionBlock},
+		{"max repeat", ScoreOption{ReviewThreshold: 1, BlockThreshold: 20, RepeatFactor: 2, MaxRepeat: 2}, "赌博赌博赌博", 3 + 6, DecisionReview},
+	}
+
+	for _, tt := range tests {
+		filter.SetScoreOption(tt.option)
+		res := filter.Score(tt.text)
+		if res.Score != tt.score || res.Decision != tt.decision {
+			t.Errorf("%s: Score = %v %v, want %v %v", tt.name, res.Score, res.Decision, tt.score, tt.decision)
+		}
+	}
+}
+
+// 敏感日期命中的评分：词库中没有同名词时按规则的权重计，未设置权重时按默认权重计
+func TestScoreDates(t *testing.T) {
+	filter, err := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDfa, Dates: []DateRule{{Month: 3, Day: 14}, {Month: 7, Day: 5, Weight: 10}}},
+	)
+	if err != nil {
+		t.Fatalf("敏感词服务启动失败, err:%v", err)
+	}
+
+	if res := filter.Score("五月三十五"); res.Score != 0 || res.Decision != DecisionPass {
+		t.Errorf("Score(五月三十五) = %v %v", res.Score, res.Decision)
+	}
+	if res := filter.Score("3月14日"); res.Score != 1 || res.Decision != DecisionReview {
+		t.Errorf("Score(3月14日) = %v %v", res.Score, res.Decision)
+	}
+	if res := filter.Score("7月5日"); res.Score != 10 || res.Decision != DecisionBlock {
+		t.Errorf("Score(7月5日) = %v %v", res.Score, res.Decision)
+	}
+}
@@ -8,90 +8,115 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 )
 
+// DefaultWeight 未单独设置权重的敏感词的默认权重
+const DefaultWeight = 1
+
+// 同步信号：每批词发送完毕后向通道额外发送两次空字符串。
+// 监听方处理完上一个词后才会接收第一个信号，处理完该信号（如等待后台重建完成）后才会接收第二个，
+// 因此 AddWord、DelWord 等方法返回时过滤器已经生效。
+const syncSignal = ""
+
+// 发送同步信号，返回时监听方已处理完此前发送的词
+func syncListener(ch chan<- string) {
+	ch <- syncSignal
+	ch <- syncSignal
+}
+
+// 词库中每个词的附加信息
+type wordEntry struct {
+	categories []string // 所属分类
+	weight     int      // 权重（严重程度），0 表示未设置
+}
+
 // MemoryModel 使用并发 map 实现的内存词库
 type MemoryModel struct {
-	store   cmap.ConcurrentMap[string, struct{}]
+	store   cmap.ConcurrentMap[string, wordEntry]
 	addChan chan string
 	delChan chan string
+
+	allow        cmap.ConcurrentMap[string, struct{}] // 白名单短语
+	allowAddChan chan string
+	allowDelChan chan string
 }
 
 // NewMemoryModel 创建新的内存模型
 func NewMemoryModel() *MemoryModel {
 	return &MemoryModel{
-		store:   cmap.New[struct{}](),
+		store:   cmap.New[wordEntry](),
 		addChan: make(chan string),
 		delChan: make(chan string),
+
+		allow:        cmap.New[struct{}](),
+		allowAddChan: make(chan string),
+		allowDelChan: make(chan string),
 	}
 }
 
 // 从本地路径加载词库文件
 func (m *MemoryModel) LoadDictPath(paths ...string) error {
-	for _, path := range paths {
-		err := func(path string) error {
-			f, err := os.Open(path)
-			defer func(f *os.File) {
-				_ = f.Close()
-			}(f)
-			if err != nil {
-				return err
-			}
+	return m.LoadDictPathCategory("", paths...)
+}
 
-			return m.LoadDict(f)
-		}(path)
-		if err != nil {
-			return err
-		}
-	}
+// 从本地路径加载词库文件，并将其中的词归入指定分类
+func (m *MemoryModel) LoadDictPathCategory(category string, paths ...string) error {
+	err := loadPaths(paths, func(reader io.Reader) error {
+		return m.loadDict(category, reader)
+	})
+	syncListener(m.addChan)
 
-	return nil
+	return err
 }
 
 // 加载嵌入式文本词库（go:embed）
 func (m *MemoryModel) LoadDictEmbed(contents ...string) error {
-	for _, con := range contents {
-		reader := strings.NewReader(con)
-		if err := m.LoadDict(reader); err != nil {
-			return err
-		}
-	}
+	return m.LoadDictEmbedCategory("", contents...)
+}
 
-	return nil
+// 加载嵌入式文本词库（go:embed），并将其中的词归入指定分类
+func (m *MemoryModel) LoadDictEmbedCategory(category string, contents ...string) error {
+	err := loadEmbeds(contents, func(reader io.Reader) error {
+		return m.loadDict(category, reader)
+	})
+	syncListener(m.addChan)
+
+	return err
 }
 
 // 从远程 HTTP 地址加载词库
 func (m *MemoryModel) LoadDictHttp(urls ...string) error {
-	for _, url := range urls {
-		err := func(url string) error {
-			httpRes, err := req.Get(url)
-			if err != nil {
-				return err
-			}
-			if httpRes == nil {
-				return errors.New("nil http response")
-			}
-			if httpRes.StatusCode != http.StatusOK {
-				return errors.New(httpRes.GetStatus())
-			}
-
-			defer func(Body io.ReadCloser) {
-				_ = Body.Close()
-			}(httpRes.Body)
+	return m.LoadDictHttpCategory("", urls...)
+}
 
-			return m.LoadDict(httpRes.Body)
-		}(url)
-		if err != nil {
-			return err
-		}
-	}
+// 从远程 HTTP 地址加载词库，并将其中的词归入指定分类
+func (m *MemoryModel) LoadDictHttpCategory(category string, urls ...string) error {
+	err := loadHttps(urls, func(reader io.Reader) error {
+		return m.loadDict(category, reader)
+	})
+	syncListener(m.addChan)
 
-	return nil
+	return err
 }
 
 // 读取词库（按行解析）
+// 每行一个词，可在词后用制表符指定权重，例如 "毒品\t5"。
 func (m *MemoryModel) LoadDict(reader io.Reader) error {
+	return m.LoadDictCategory("", reader)
+}
+
+// 读取词库（按行解析），并将其中的词归入指定分类
+func (m *MemoryModel) LoadDictCategory(category string, reader io.Reader) error {
+	err := m.loadDict(category, reader)
+	syncListener(m.addChan)
+
+	return err
+}
+
+// 读取词库并逐个发送给监听方，多个词库加载完毕后再统一发送同步信号
+func (m *MemoryModel) loadDict(category string, reader io.Reader) error {
 	buf := bufio.NewReader(reader)
 	for {
 		line, _, err := buf.ReadLine()
@@ -102,13 +127,29 @@ func (m *MemoryModel) LoadDict(reader io.Reader) error {
 			break
 		}
 
-		m.store.Set(string(line), struct{}{})
-		m.addChan <- string(line)
+		word, weight := parseLine(string(line))
+		m.set(word, category, weight)
+		m.addChan <- word
 	}
 
 	return nil
 }
 
+// 解析词库中的一行，返回词与权重（未指定时为 0）
+func parseLine(line string) (string, int) {
+	i := strings.LastIndexByte(line, '\t')
+	if i < 0 {
+		return line, 0
+	}
+
+	weight, err := strconv.Atoi(strings.TrimSpace(line[i+1:]))
+	if err != nil || weight < 0 {
+		return line, 0
+	}
+
+	return line[:i], weight
+}
+
 // 返回所有敏感词的读取通道（可用于初始化加载）
 func (m *MemoryModel) ReadChan() <-chan string {
 	ch := make(chan string)
@@ -146,10 +187,31 @@ func (m *MemoryModel) GetDelChan() <-chan string {
 
 // 添加自定义敏感词
 func (m *MemoryModel) AddWord(words ...string) error {
+	return m.AddWordCategory("", words...)
+}
+
+// 添加自定义敏感词，并归入指定分类
+func (m *MemoryModel) AddWordCategory(category string, words ...string) error {
+	for _, word := range words {
+		m.set(word, category, 0)
+		m.addChan <- word
+	}
+	syncListener(m.addChan)
+
+	return nil
+}
+
+// 添加自定义敏感词，并设置其权重
+func (m *MemoryModel) AddWordWeight(weight int, words ...string) error {
+	if weight < 0 {
+		return errors.New("invalid word weight")
+	}
+
 	for _, word := range words {
-		m.store.Set(word, struct{}{})
+		m.set(word, "", weight)
 		m.addChan <- word
 	}
+	syncListener(m.addChan)
 
 	return nil
 }
@@ -160,6 +222,116 @@ func (m *MemoryModel) DelWord(words ...string) error {
 		m.store.Remove(word)
 		m.delChan <- word
 	}
+	syncListener(m.delChan)
+
+	return nil
+}
+
+// 获取敏感词所属的分类，未分类或不存在时返回 nil
+func (m *MemoryModel) GetCategories(word string) []string {
+	entry, ok := m.store.Get(word)
+	if !ok {
+		return nil
+	}
+
+	return entry.categories
+}
+
+// 获取敏感词的权重，未单独设置时返回 DefaultWeight，不存在时返回 0
+func (m *MemoryModel) GetWeight(word string) int {
+	entry, ok := m.store.Get(word)
+	if !ok {
+		return 0
+	}
+	if entry.weight == 0 {
+		return DefaultWeight
+	}
+
+	return entry.weight
+}
+
+// 写入敏感词，已存在时合并分类，weight 大于 0 时覆盖权重
+func (m *MemoryModel) set(word, category string, weight int) {
+	m.store.Upsert(word, wordEntry{}, func(exist bool, entry wordEntry, _ wordEntry) wordEntry {
+		if weight > 0 {
+			entry.weight = weight
+		}
+		if category == "" {
+			return entry
+		}
+		for _, c := range entry.categories {
+			if c == category {
+				return entry
+			}
+		}
+
+		// 复制一份再追加，避免与已返回给调用方的切片共享底层数组
+		categories := make([]string, len(entry.categories), len(entry.categories)+1)
+		copy(categories, entry.categories)
+		entry.categories = append(categories, category)
+		return entry
+	})
+}
+
+// 依次打开本地文件并交给 load 读取
+func loadPaths(paths []string, load func(reader io.Reader) error) error {
+	for _, path := range paths {
+		err := func(path string) error {
+			f, err := os.Open(path)
+			defer func(f *os.File) {
+				_ = f.Close()
+			}(f)
+			if err != nil {
+				return err
+			}
+
+			return load(f)
+		}(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 依次将嵌入式文本交给 load 读取
+func loadEmbeds(contents []string, load func(reader io.Reader) error) error {
+	for _, con := range contents {
+		reader := strings.NewReader(con)
+		if err := load(reader); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 依次请求远程地址并将响应内容交给 load 读取
+func loadHttps(urls []string, load func(reader io.Reader) error) error {
+	for _, url := range urls {
+		err := func(url string) error {
+			httpRes, err := req.Get(url)
+			if err != nil {
+				return err
+			}
+			if httpRes == nil {
+				return errors.New("nil http response")
+			}
+			if httpRes.StatusCode != http.StatusOK {
+				return errors.New(httpRes.GetStatus())
+			}
+
+			defer func(Body io.ReadCloser) {
+				_ = Body.Close()
+			}(httpRes.Body)
+
+			return load(httpRes.Body)
+		}(url)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"bufio"
+	"io"
+)
+
+// 从本地路径加载白名单短语文件
+func (m *MemoryModel) LoadAllowDictPath(paths ...string) error {
+	return loadPaths(paths, m.LoadAllowDict)
+}
+
+// 加载嵌入式白名单短语（go:embed）
+func (m *MemoryModel) LoadAllowDictEmbed(contents ...string) error {
+	return loadEmbeds(contents, m.LoadAllowDict)
+}
+
+// 从远程 HTTP 地址加载白名单短语
+func (m *MemoryModel) LoadAllowDictHttp(urls ...string) error {
+	return loadHttps(urls, m.LoadAllowDict)
+}
+
+// 读取白名单短语（按行解析）
+func (m *MemoryModel) LoadAllowDict(reader io.Reader) error {
+	buf := bufio.NewReader(reader)
+	for {
+		line, _, err := buf.ReadLine()
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			break
+		}
+
+		m.allow.Set(string(line), struct{}{})
+		m.allowAddChan <- string(line)
+	}
+	syncListener(m.allowAddChan)
+
+	return nil
+}
+
+// 获取所有白名单短语（字符串数组）
+func (m *MemoryModel) ReadAllowString() []string {
+	res := make([]string, 0, m.allow.Count())
+
+	for key := range m.allow.Items() {
+		res = append(res, key)
+	}
+
+	return res
+}
+
+// 获取新增白名单短语通道
+func (m *MemoryModel) GetAllowAddChan() <-chan string {
+	return m.allowAddChan
+}
+
+// 获取删除白名单短语通道
+func (m *MemoryModel) GetAllowDelChan() <-chan string {
+	return m.allowDelChan
+}
+
+// 添加白名单短语，完全落在这些短语内的命中会被忽略
+func (m *MemoryModel) AddAllowWord(words ...string) error {
+	for _, word := range words {
+		m.allow.Set(word, struct{}{})
+		m.allowAddChan <- word
+	}
+	syncListener(m.allowAddChan)
+
+	return nil
+}
+
+// 删除白名单短语
+func (m *MemoryModel) DelAllowWord(words ...string) error {
+	for _, word := range words {
+		m.allow.Remove(word)
+		m.allowDelChan <- word
+	}
+	syncListener(m.allowDelChan)
+
+	return nil
+}
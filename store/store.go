@@ -10,20 +10,56 @@ type (
 		LoadDictEmbed(contents ...string) error
 		// LoadDictHttp 从远程 URL 加载词库内容（支持多个 URL）
 		LoadDictHttp(url ...string) error
-		// LoadDict 从 io.Reader 加载词库内容（按行读取）
+		// LoadDict 从 io.Reader 加载词库内容（按行读取，可用制表符在词后指定权重）
 		LoadDict(reader io.Reader) error
+		// LoadDictPathCategory 从本地路径加载词库文件，并将其中的词归入指定分类
+		LoadDictPathCategory(category string, path ...string) error
+		// LoadDictEmbedCategory 加载嵌入式词库内容，并将其中的词归入指定分类
+		LoadDictEmbedCategory(category string, contents ...string) error
+		// LoadDictHttpCategory 从远程 URL 加载词库内容，并将其中的词归入指定分类
+		LoadDictHttpCategory(category string, url ...string) error
+		// LoadDictCategory 从 io.Reader 加载词库内容，并将其中的词归入指定分类
+		LoadDictCategory(category string, reader io.Reader) error
 		// ReadChan 返回一个通道，逐个输出当前存储中的所有敏感词（可用于异步加载到过滤器）
 		ReadChan() <-chan string
 		// ReadString 以字符串数组形式返回当前所有敏感词
 		ReadString() []string
 		// GetAddChan 获取新增敏感词的事件通道（用于实时监听词库变更）
+		// 每批词之后会发送一个空字符串作为同步信号，监听方应忽略空字符串
 		GetAddChan() <-chan string
 		// GetDelChan 获取删除敏感词的事件通道（用于实时监听词库变更）
 		GetDelChan() <-chan string
 		// AddWord 添加一个或多个敏感词
 		AddWord(words ...string) error
+		// AddWordCategory 添加一个或多个敏感词，并归入指定分类
+		AddWordCategory(category string, words ...string) error
+		// AddWordWeight 添加一个或多个敏感词，并设置其权重（严重程度）
+		AddWordWeight(weight int, words ...string) error
 		// DelWord 删除一个或多个敏感词
 		DelWord(words ...string) error
+		// GetCategories 获取敏感词所属的分类（一个词可属于多个分类）
+		GetCategories(word string) []string
+		// GetWeight 获取敏感词的权重（严重程度）
+		GetWeight(word string) int
+
+		// LoadAllowDictPath 从本地路径加载白名单短语文件
+		LoadAllowDictPath(path ...string) error
+		// LoadAllowDictEmbed 加载嵌入式白名单短语内容
+		LoadAllowDictEmbed(contents ...string) error
+		// LoadAllowDictHttp 从远程 URL 加载白名单短语
+		LoadAllowDictHttp(url ...string) error
+		// LoadAllowDict 从 io.Reader 加载白名单短语（按行读取）
+		LoadAllowDict(reader io.Reader) error
+		// ReadAllowString 以字符串数组形式返回当前所有白名单短语
+		ReadAllowString() []string
+		// GetAllowAddChan 获取新增白名单短语的事件通道
+		GetAllowAddChan() <-chan string
+		// GetAllowDelChan 获取删除白名单短语的事件通道
+		GetAllowDelChan() <-chan string
+		// AddAllowWord 添加一个或多个白名单短语，完全落在短语内的命中会被忽略
+		AddAllowWord(words ...string) error
+		// DelAllowWord 删除一个或多个白名单短语
+		DelAllowWord(words ...string) error
 	}
 )
 
@@ -1,6 +1,11 @@
 package go_sensitive_word
 
-import "regexp"
+import (
+	"github.com/zmexing/go-sensitive-word/normalize"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
 
 // HasEmail 判断字符串中是否存在邮箱地址
 func HasEmail(s string) bool {
@@ -33,6 +38,8 @@ func MaskURL(s string) string {
 }
 
 // HasDigit 判断字符串中是否存在指定个数的数字（大于等于该数字）
+// 只统计 ASCII 数字，不经过 normalize.Numerals：“一”“两”等汉字数字在普通文本中极为常见，按数字统计会大量误报；
+// 需要识别汉字数字、全角数字等写法的联系方式时使用 HasPhone。
 func HasDigit(s string, count int) bool {
 	digitRegex := regexp.MustCompile(`\d`)
 	matches := digitRegex.FindAllString(s, -1)
@@ -40,6 +47,7 @@ func HasDigit(s string, count int) bool {
 }
 
 // MaskDigit 将字符串中存在的数字替换成 "*"
+// 与 HasDigit 相同，只替换 ASCII 数字，“一个人”等普通文本不受影响。
 func MaskDigit(s string) string {
 	digitRegex := regexp.MustCompile(`\d`)
 	masked := digitRegex.ReplaceAllStringFunc(s, func(digit string) string {
@@ -48,6 +56,56 @@ func MaskDigit(s string) string {
 	return masked
 }
 
+// 中国大陆手机号，号段之间允许以空格或连字符分隔
+var phoneRegex = regexp.MustCompile(`1[3-9]\d(?:[- ]?\d{4}){2}`)
+
+// HasPhone 判断字符串中是否存在手机号，汉字数字（“幺三八”“壹叁捌”）、带圈数字、全角数字等写法同样识别
+func HasPhone(s string) bool {
+	return len(findPhones(s)) > 0
+}
+
+// MaskPhone 将字符串中存在的手机号替换成 "*"，汉字数字等写法一同替换
+func MaskPhone(s string) string {
+	runes := []rune(s)
+	var b []rune
+	last := 0
+	for _, span := range findPhones(s) {
+		b = append(b, runes[last:span.Start]...)
+		b = append(b, []rune("***")...)
+		last = span.End
+	}
+	if last == 0 {
+		return s
+	}
+
+	return string(append(b, runes[last:]...))
+}
+
+// 查找手机号在原文中的区间，先经过 normalize.Numerals 将各种数字写法转换为 ASCII 数字
+// 前后紧邻其他数字的不算手机号，避免误判身份证号、银行卡号中的片段。
+func findPhones(s string) []normalize.Span {
+	text := normalize.NewText(normalize.Numerals, []rune(s))
+	normalized := string(text.Runes)
+
+	var spans []normalize.Span
+	for _, loc := range phoneRegex.FindAllStringIndex(normalized, -1) {
+		before, _ := utf8.DecodeLastRuneInString(normalized[:loc[0]])
+		after, _ := utf8.DecodeRuneInString(normalized[loc[1]:])
+		if isASCIIDigit(before) || isASCIIDigit(after) {
+			continue
+		}
+		start := utf8.RuneCountInString(normalized[:loc[0]])
+		end := start + utf8.RuneCountInString(normalized[loc[0]:loc[1]])
+		spans = append(spans, text.Span(start, end))
+	}
+
+	return spans
+}
+
+func isASCIIDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
 // HasWechatID 判断字符串中是否存在微信号
 func HasWechatID(s string) bool {
 	wechatRegex := regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9_-]{5,19}`)
@@ -62,3 +120,51 @@ func MaskWechatID(s string) string {
 	})
 	return masked
 }
+
+// HasMixedScript 判断字符串中是否存在混用多种文字的单词（如“fuсk”中混入了西里尔字母“с”），这通常是刻意规避过滤
+// 单词为连续的字母（可带组合符号），数字、空白与标点视为分隔；汉字、假名与谚文不参与判断，因此“法lun功”不算混用。
+func HasMixedScript(s string) bool {
+	var current *unicode.RangeTable
+	for _, r := range s {
+		if unicode.In(r, unicode.Mn, unicode.Me) {
+			continue
+		}
+		script := letterScript(r)
+		if script == nil {
+			current = nil
+			continue
+		}
+		if current != nil && script != current {
+			return true
+		}
+		current = script
+	}
+
+	return false
+}
+
+// 返回字母所属的文字，非字母或汉字、假名、谚文返回 nil
+func letterScript(r rune) *unicode.RangeTable {
+	if r < 0x80 {
+		if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') {
+			return unicode.Latin
+		}
+		return nil
+	}
+	if !unicode.IsLetter(r) || unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul, unicode.Bopomofo) {
+		return nil
+	}
+
+	for _, script := range []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic, unicode.Greek} {
+		if unicode.Is(script, r) {
+			return script
+		}
+	}
+	for _, script := range unicode.Scripts {
+		if script != unicode.Common && script != unicode.Inherited && unicode.Is(script, r) {
+			return script
+		}
+	}
+
+	return nil
+}
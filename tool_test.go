@@ -35,6 +35,37 @@ func TestDigit(t *testing.T) {
 	} else {
 		fmt.Println("字符串中不存在指定个数的数字。")
 	}
+
+	// 只处理 ASCII 数字，汉字数字、全角数字由 HasPhone 识别
+	if HasDigit("一两个６", 1) {
+		t.Error("HasDigit counted non-ASCII numerals")
+	}
+	if got, want := MaskDigit("一个人有6个６"), "一个人有*个６"; got != want {
+		t.Errorf("MaskDigit = %q, want %q", got, want)
+	}
+}
+
+func TestPhone(t *testing.T) {
+	tests := []struct {
+		input  string
+		masked string
+	}{
+		{"电话13812345678", "电话***"},
+		{"电话138-1234-5678", "电话***"},
+		{"电话幺三八幺二三四五六七八", "电话***"},
+		{"电话壹叁捌1234伍陆柒捌", "电话***"},
+		{"电话①③⑧①②③④⑤⑥⑦⑧！", "电话***！"},
+		{"身份证110101199003071234", "身份证110101199003071234"},
+		{"没有手机号", "没有手机号"},
+	}
+	for _, tt := range tests {
+		if got, want := HasPhone(tt.input), tt.masked != tt.input; got != want {
+			t.Errorf("HasPhone(%q) = %v, want %v", tt.input, got, want)
+		}
+		if got := MaskPhone(tt.input); got != tt.masked {
+			t.Errorf("MaskPhone(%q) = %q, want %q", tt.input, got, tt.masked)
+		}
+	}
 }
 
 func TestWechat(t *testing.T) {
@@ -46,3 +77,23 @@ func TestWechat(t *testing.T) {
 		fmt.Println("字符串中不存在微信号。")
 	}
 }
+
+func TestMixedScript(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"what the fuck", false},
+		{"what the fu\u0441k", true}, // 西里尔字母 с
+		{"\u03bfk", true},            // 希腊字母 ο
+		{"привет мир", false},
+		{"法lun功", false},
+		{"café naïve", false},
+		{"sh1t", false},
+	}
+	for _, tt := range tests {
+		if got := HasMixedScript(tt.input); got != tt.want {
+			t.Errorf("HasMixedScript(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}